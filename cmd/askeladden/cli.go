@@ -0,0 +1,19 @@
+package main
+
+import (
+	"fmt"
+
+	"askeladden/internal/config"
+)
+
+// runSubcommand køyrer ein underkommando frå kommandolinja i staden for boten.
+func runSubcommand(cfg *config.Config, args []string) error {
+	switch args[0] {
+	case "migrate":
+		return runMigrate(cfg, args[1:])
+	case "ordliste":
+		return runOrdliste(cfg, args[1:])
+	default:
+		return fmt.Errorf("ukjend underkommando %q (tilgjengeleg: migrate, ordliste)", args[0])
+	}
+}
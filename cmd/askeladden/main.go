@@ -33,6 +33,14 @@ func main() {
 		log.Fatalf("[MAIN] Kunne ikkje laste konfigurasjon: %v", err)
 	}
 
+	// Køyr underkommandoar (t.d. "migrate status") i staden for boten
+	if len(os.Args) > 1 {
+		if err := runSubcommand(cfg, os.Args[1:]); err != nil {
+			log.Fatalf("[MAIN] %v", err)
+		}
+		return
+	}
+
 	// Opprett database-tilkobling
 	db, err := database.New(cfg)
 	if err != nil {
@@ -62,6 +70,8 @@ func main() {
 	// Set opp hendingshandterarar
 	session.AddHandler(botHandlers.Ready)
 	session.AddHandler(botHandlers.MessageCreate)
+	session.AddHandler(botHandlers.MessageUpdate)
+	session.AddHandler(botHandlers.MessageDelete)
 	session.AddHandler(botHandlers.ReactionAdd)
 	session.AddHandler(botHandlers.ReactionRemove)
 	session.AddHandler(botHandlers.InteractionCreate)
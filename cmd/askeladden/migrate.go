@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"askeladden/internal/config"
+	"askeladden/internal/database"
+)
+
+// runMigrate handsamar "migrate up" og "migrate status".
+func runMigrate(cfg *config.Config, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("bruk: askeladden migrate up|status")
+	}
+
+	db, err := database.Open(cfg)
+	if err != nil {
+		return fmt.Errorf("kunne ikkje kople til database: %w", err)
+	}
+	defer db.Close()
+
+	switch args[0] {
+	case "up":
+		applied, err := db.Migrate()
+		if err != nil {
+			return err
+		}
+		log.Printf("[MIGRATE] %d migrasjonar køyrde", applied)
+		return nil
+	case "status":
+		states, err := db.MigrationStatus()
+		if err != nil {
+			return err
+		}
+		printMigrationStatus(os.Stdout, states)
+		return nil
+	default:
+		return fmt.Errorf("ukjend migrate-kommando %q (bruk up eller status)", args[0])
+	}
+}
+
+// printMigrationStatus skriv ei linje per migrasjon med versjon, status og skildring
+func printMigrationStatus(w io.Writer, states []database.MigrationState) {
+	for _, state := range states {
+		status := "ventar"
+		if state.AppliedAt != nil {
+			status = "køyrd " + state.AppliedAt.Format("2006-01-02 15:04:05")
+		}
+		fmt.Fprintf(w, "%3d  %-22s  %s\n", state.Version, status, state.Description)
+	}
+}
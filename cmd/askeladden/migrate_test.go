@@ -0,0 +1,32 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"askeladden/internal/database"
+)
+
+func TestPrintMigrationStatus(t *testing.T) {
+	applied := time.Date(2025, 3, 1, 12, 30, 0, 0, time.UTC)
+	states := []database.MigrationState{
+		{Version: 1, Description: "create tables", AppliedAt: &applied},
+		{Version: 14, Description: "index hits"},
+	}
+
+	var out bytes.Buffer
+	printMigrationStatus(&out, states)
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("output = %q", out.String())
+	}
+	if lines[0] != "  1  køyrd 2025-03-01 12:30:00  create tables" {
+		t.Errorf("applied line = %q", lines[0])
+	}
+	if !strings.HasPrefix(lines[1], " 14  ventar ") || !strings.HasSuffix(lines[1], "  index hits") {
+		t.Errorf("pending line = %q", lines[1])
+	}
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"askeladden/internal/bannedwords"
+	"askeladden/internal/config"
+	"askeladden/internal/database"
+)
+
+const ordlisteUsage = "bruk: askeladden ordliste import [-dry-run] [-importer namn] <fil.csv|fil.yaml>\n" +
+	"      askeladden ordliste export [-format csv|yaml] [fil]\n\n" +
+	cliImportNote
+
+// cliImportNote is shown after a command line import, since the running bot
+// only reads the banned words into memory at start and after changes it makes itself
+const cliImportNote = "Ein bot som køyrer, ser ikkje ord som er importerte her før han vert starta på nytt. Bruk !ordimport for å importere til ein bot som køyrer."
+
+// runOrdliste handsamar "ordliste import" og "ordliste export".
+func runOrdliste(cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%s", ordlisteUsage)
+	}
+
+	switch args[0] {
+	case "import":
+		return runOrdlisteImport(cfg, args[1:])
+	case "export":
+		return runOrdlisteExport(cfg, args[1:])
+	default:
+		return fmt.Errorf("ukjend ordliste-kommando %q\n%s", args[0], ordlisteUsage)
+	}
+}
+
+// runOrdlisteImport importerer ei ordliste frå fil
+func runOrdlisteImport(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("ordliste import", flag.ContinueOnError)
+	dryRun := flags.Bool("dry-run", false, "vis endringane utan å lagre")
+	importer := flags.String("importer", "ordliste-import", "namn som vert lagra som godkjennar")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return fmt.Errorf("%s", ordlisteUsage)
+	}
+	path := flags.Arg(0)
+
+	format, err := bannedwords.FormatFromFilename(path)
+	if err != nil {
+		return err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	entries, err := bannedwords.ReadList(file, format)
+	if err != nil {
+		return fmt.Errorf("ugyldig ordliste: %w", err)
+	}
+
+	db, err := database.New(cfg)
+	if err != nil {
+		return fmt.Errorf("kunne ikkje kople til database: %w", err)
+	}
+	defer db.Close()
+
+	diff, err := bannedwords.ImportList(db, entries, *importer, *importer, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(diff.String())
+	if *dryRun {
+		log.Printf("[ORDLISTE] Prøvekøyring: %d nye, %d endra, %d uendra (ingenting lagra)", len(diff.Added), len(diff.Updated), len(diff.Unchanged))
+	} else {
+		log.Printf("[ORDLISTE] Importert: %d nye, %d endra, %d uendra", len(diff.Added), len(diff.Updated), len(diff.Unchanged))
+		if diff.HasChanges() {
+			log.Printf("[ORDLISTE] %s", cliImportNote)
+		}
+	}
+	return nil
+}
+
+// runOrdlisteExport skriv dei godkjende orda til fil eller stdout
+func runOrdlisteExport(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("ordliste export", flag.ContinueOnError)
+	format := flags.String("format", "", "csv eller yaml (standard: frå filnamnet, elles csv)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 1 {
+		return fmt.Errorf("%s", ordlisteUsage)
+	}
+
+	path := flags.Arg(0)
+	if path != "" && *format == "" {
+		detected, err := bannedwords.FormatFromFilename(path)
+		if err != nil {
+			return err
+		}
+		*format = detected
+	}
+	if *format == "" {
+		*format = bannedwords.FormatCSV
+	}
+
+	db, err := database.New(cfg)
+	if err != nil {
+		return fmt.Errorf("kunne ikkje kople til database: %w", err)
+	}
+	defer db.Close()
+
+	entries, err := bannedwords.ExportList(db)
+	if err != nil {
+		return err
+	}
+
+	// Write the whole list before the file is touched, so a failed export
+	// leaves an earlier export as it was
+	var list bytes.Buffer
+	if err := bannedwords.WriteList(&list, *format, entries); err != nil {
+		return err
+	}
+	if path == "" {
+		_, err = list.WriteTo(os.Stdout)
+	} else {
+		err = os.WriteFile(path, list.Bytes(), 0o644)
+	}
+	if err != nil {
+		return err
+	}
+	log.Printf("[ORDLISTE] Eksporterte %d ord", len(entries))
+	return nil
+}
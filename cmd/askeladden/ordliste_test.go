@@ -0,0 +1,49 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"askeladden/internal/config"
+	"askeladden/internal/database"
+)
+
+func TestFailedExportKeepsEarlierFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "ordliste.csv")
+	os.WriteFile(path, []byte("word,reason\nikke,bokmål\n"), 0o644)
+
+	cfg := &config.Config{}
+	cfg.Database.Driver = database.DriverSQLite
+	cfg.Database.Path = filepath.Join(dir, "finst-ikkje", "askeladden.db")
+
+	if err := runOrdlisteExport(cfg, []string{path}); err == nil {
+		t.Fatal("export without a database should fail")
+	}
+	if data, _ := os.ReadFile(path); !strings.Contains(string(data), "ikke") {
+		t.Errorf("earlier export = %q, want it untouched", data)
+	}
+}
+
+func TestExportWritesFile(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{}
+	cfg.Database.Driver = database.DriverSQLite
+	cfg.Database.Path = filepath.Join(dir, "askeladden.db")
+	db, err := database.New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.ImportBannedWord("ikke", "bokmål", nil, "importør", "Importør")
+	db.Close()
+
+	path := filepath.Join(dir, "ordliste.yaml")
+	if err := runOrdlisteExport(cfg, []string{path}); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(path); !strings.Contains(string(data), "ikke") {
+		t.Errorf("export = %q", data)
+	}
+}
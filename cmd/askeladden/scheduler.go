@@ -3,6 +3,8 @@ package main
 import (
 	"askeladden/internal/bot"
 	"askeladden/internal/bot/services"
+	"askeladden/internal/database"
+	"askeladden/internal/questions"
 	"fmt"
 	"log"
 	"time"
@@ -53,8 +55,9 @@ func scheduleDailyQuestion(b *bot.Bot) *time.Ticker {
 		inactivityHours: time.Duration(b.Config.Scheduler.InactivityHours) * time.Hour,
 	}
 
-	log.Printf("[SCHEDULER] Advanced scheduler started - Timezone: %s, Morning: %s, Evening: %s, Inactivity: %v",
-		timezone.String(), b.Config.Scheduler.MorningTime, b.Config.Scheduler.EveningTime, state.inactivityHours)
+	log.Printf("[SCHEDULER] Advanced scheduler started - Timezone: %s, Morning: %s, Evening: %s, Inactivity: %v, Strategy: %s",
+		timezone.String(), b.Config.Scheduler.MorningTime, b.Config.Scheduler.EveningTime, state.inactivityHours,
+		questions.FromConfig(b.Config).Name())
 
 	// Check every 30 minutes
 	ticker := time.NewTicker(30 * time.Minute)
@@ -70,10 +73,15 @@ func scheduleDailyQuestion(b *bot.Bot) *time.Ticker {
 	return ticker
 }
 
-// triggerDailyQuestion handles the daily question logic.
-func triggerDailyQuestion(b *bot.Bot) {
-	// Retrieve least asked approved question
-	question, err := b.Database.GetLeastAskedApprovedQuestion()
+// triggerDailyQuestion handles the daily question logic. trigger is the
+// database.QuestionTrigger the post is recorded with in the question history.
+func triggerDailyQuestion(b *bot.Bot, trigger string) {
+	// Pick the question with the configured strategy
+	question, err := questions.Next(b.Database, questions.FromConfig(b.Config), time.Now())
+	if err == questions.ErrNoneReady {
+		log.Println("[SCHEDULER] Every approved question is still in cooldown, skipping the day.")
+		return
+	}
 	if err != nil {
 		log.Printf("[SCHEDULER] Failed to retrieve daily question: %v", err)
 		return
@@ -113,7 +121,8 @@ func triggerDailyQuestion(b *bot.Bot) {
 			mention = "<@&" + roleID + ">"
 		}
 
-		services.SendDailyQuestion(b, question, mention)
+		sent := services.SendDailyQuestion(b, question, mention)
+		services.RecordQuestionPost(b, question, sent, trigger, "")
 		log.Printf("[SCHEDULER] Daily question sent: %s", question.Question)
 	} else {
 		log.Println("[SCHEDULER] Default channel not configured.")
@@ -139,11 +148,13 @@ func checkAndTriggerDailyQuestion(b *bot.Bot, state *SchedulerState) {
 
 	shouldTrigger := false
 	reason := ""
+	trigger := ""
 
 	// Condition 1: It's morning time and we haven't posted today yet
 	if currentTime.After(morningTime) && currentTime.Before(morningTime.Add(30*time.Minute)) && !hasPostedToday {
 		shouldTrigger = true
 		reason = fmt.Sprintf("morning schedule (%s)", b.Config.Scheduler.MorningTime)
+		trigger = database.QuestionTriggerMorning
 	}
 
 	// Condition 2: Inactivity threshold reached, but only if:
@@ -154,6 +165,7 @@ func checkAndTriggerDailyQuestion(b *bot.Bot, state *SchedulerState) {
 		if currentTime.After(morningTime) && currentTime.Before(eveningTime) {
 			shouldTrigger = true
 			reason = fmt.Sprintf("inactivity threshold (%v since last activity, before nighttime)", timeSinceLastActivity.Round(time.Minute))
+			trigger = database.QuestionTriggerInactivity
 		} else if currentTime.After(eveningTime) {
 			// After nighttime - log but don't trigger
 			log.Printf("[SCHEDULER] Inactivity threshold reached (%v) but nighttime reached (%s) - waiting until tomorrow morning",
@@ -163,7 +175,7 @@ func checkAndTriggerDailyQuestion(b *bot.Bot, state *SchedulerState) {
 
 	if shouldTrigger {
 		log.Printf("[SCHEDULER] Triggering daily question due to: %s", reason)
-		triggerDailyQuestion(b)
+		triggerDailyQuestion(b, trigger)
 		state.lastDailyPost = now
 
 		// Reset activity timer when we post
@@ -0,0 +1,118 @@
+package bannedwords
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Exception is a context where a banned word is allowed: a fixed expression,
+// a name or a quoted bokmål text. A match inside an exception is not reported.
+//
+//	Ikke Ro AS        a phrase, matched like a pattern and ignoring case
+//	Ikke* AS          phrases may use stems too
+//	/«[^»]*»/         a regular expression between slashes, ignoring case
+type Exception struct {
+	source string
+	phrase Pattern
+	regex  *regexp.Regexp
+}
+
+// ParseException validates and compiles an exception
+func ParseException(exception string) (Exception, error) {
+	exception = strings.TrimSpace(exception)
+	if body, ok := regexBody(exception); ok {
+		if body == "" {
+			return Exception{}, fmt.Errorf("tomt regulært uttrykk")
+		}
+		regex, err := regexp.Compile("(?i)" + body)
+		if err != nil {
+			return Exception{}, fmt.Errorf("«%s» er ikkje eit gyldig regulært uttrykk: %v", exception, err)
+		}
+		return Exception{source: exception, regex: regex}, nil
+	}
+
+	phrase, err := ParsePattern(exception)
+	if err != nil {
+		return Exception{}, err
+	}
+	return Exception{source: phrase.String(), phrase: phrase}, nil
+}
+
+// regexBody returns the expression inside /.../
+func regexBody(exception string) (string, bool) {
+	if len(exception) < 2 || !strings.HasPrefix(exception, "/") || !strings.HasSuffix(exception, "/") {
+		return "", false
+	}
+	return exception[1 : len(exception)-1], true
+}
+
+// String returns the exception in its normalised written form
+func (e Exception) String() string {
+	return e.source
+}
+
+// covers reports whether the span start:end of content lies inside an
+// occurrence of the exception. Tokens are the tokens of content.
+func (e Exception) covers(content string, tokens []Token, start, end int) bool {
+	if e.regex != nil {
+		for _, loc := range e.regex.FindAllStringIndex(content, -1) {
+			if loc[0] <= start && end <= loc[1] {
+				return true
+			}
+		}
+		return false
+	}
+
+	for i := range tokens {
+		if tokens[i].Start > start {
+			break
+		}
+		if n := e.phrase.matchAt(tokens, i); n > 0 && end <= tokens[i+n-1].End {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseExceptionList splits a comma- or newline-separated list and validates
+// each exception. Commas inside a /regular expression/ don't split it.
+// Returns the normalised exceptions.
+func ParseExceptionList(list string) ([]string, error) {
+	var exceptions []string
+	for _, field := range splitExceptionList(list) {
+		if strings.TrimSpace(field) == "" {
+			continue
+		}
+		e, err := ParseException(field)
+		if err != nil {
+			return nil, err
+		}
+		exceptions = append(exceptions, e.String())
+	}
+	return exceptions, nil
+}
+
+// splitExceptionList splits on commas and newlines outside of /.../
+func splitExceptionList(list string) []string {
+	var fields []string
+	var field strings.Builder
+	inRegex := false
+	for i := 0; i < len(list); i++ {
+		c := list[i]
+		switch {
+		case inRegex && c == '\\' && i+1 < len(list):
+			field.WriteByte(c)
+			i++
+			c = list[i]
+		case c == '/' && (inRegex || strings.TrimSpace(field.String()) == ""):
+			inRegex = !inRegex
+		case !inRegex && (c == ',' || c == '\n'):
+			fields = append(fields, field.String())
+			field.Reset()
+			continue
+		}
+		field.WriteByte(c)
+	}
+	return append(fields, field.String())
+}
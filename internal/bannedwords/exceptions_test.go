@@ -0,0 +1,97 @@
+package bannedwords
+
+import (
+	"strings"
+	"testing"
+
+	"askeladden/internal/database"
+)
+
+// sourceWithAllowlist serves fixed banned words and allowed phrases
+type sourceWithAllowlist struct {
+	staticSource
+	allowed []string
+}
+
+func (s sourceWithAllowlist) GetAllowedPhrases() ([]*database.AllowedPhrase, error) {
+	var phrases []*database.AllowedPhrase
+	for _, p := range s.allowed {
+		phrases = append(phrases, &database.AllowedPhrase{Phrase: p})
+	}
+	return phrases, nil
+}
+
+func TestParseException(t *testing.T) {
+	valid := map[string]string{
+		"Ikke  Ro AS":  "Ikke Ro AS",
+		"bok* og blad": "bok* og blad",
+		" /«[^»]*»/ ":  "/«[^»]*»/",
+	}
+	for input, want := range valid {
+		e, err := ParseException(input)
+		if err != nil {
+			t.Errorf("ParseException(%q) failed: %v", input, err)
+		} else if e.String() != want {
+			t.Errorf("ParseException(%q) = %q, want %q", input, e.String(), want)
+		}
+	}
+
+	for _, input := range []string{"", "//", "/(/", "ord!"} {
+		if _, err := ParseException(input); err == nil {
+			t.Errorf("ParseException(%q) should fail", input)
+		}
+	}
+}
+
+func TestParseExceptionListKeepsCommasInRegex(t *testing.T) {
+	got, err := ParseExceptionList("Ikke Ro AS, /ikke{1,2}/,\nhvordan går")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(got, "|") != "Ikke Ro AS|/ikke{1,2}/|hvordan går" {
+		t.Errorf("ParseExceptionList = %q", got)
+	}
+}
+
+func TestMatcherSkipsExceptions(t *testing.T) {
+	ikke := approved(1, "ikke")
+	ikke.Exceptions = []string{"Ikke Ro AS", "/\\bikke-\\w+/"}
+	m := NewMatcher(sourceWithAllowlist{
+		staticSource: staticSource{ikke, approved(2, "hvordan")},
+		allowed:      []string{"/«[^»]*»/", "hvordan går det"},
+	})
+
+	tests := []struct {
+		content string
+		want    string
+	}{
+		{"Eg jobbar i Ikke Ro AS", ""},
+		{"Ikke Ro AS seier ikke noko", "ikke"},
+		{"Ro AS ikke", "ikke"},
+		{"Eit ikke-ord", ""},
+		{"Han skreiv «ikke hvordan» på bokmål", ""},
+		{"«ikke» og ikke", "ikke"},
+		{"Hvordan går det", ""},
+		{"Hvordan går det? Hvordan", "hvordan"},
+		{"`ikke` «ikke»", ""},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, match := range m.Matches(tt.content) {
+			got = append(got, match.Word.Word)
+		}
+		if strings.Join(got, " ") != tt.want {
+			t.Errorf("Matches(%q) = %v, want %q", tt.content, got, tt.want)
+		}
+	}
+}
+
+func TestMatcherSkipsInvalidExceptions(t *testing.T) {
+	ikke := approved(1, "ikke")
+	ikke.Exceptions = []string{"/(/"}
+	m := NewMatcher(sourceWithAllowlist{staticSource: staticSource{ikke}, allowed: []string{"ord!"}})
+
+	if found := m.FindAll("ikke"); len(found) != 1 {
+		t.Errorf("FindAll = %v, want the word despite broken exceptions", words(found))
+	}
+}
@@ -0,0 +1,82 @@
+package bannedwords
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+)
+
+// Spans of a Discord message that are not the author's own prose. They are
+// masked out before tokenizing, so nobody is warned about a word inside a code
+// block, a quote of someone else, a link, a mention or an emoji name.
+var (
+	codeBlockPattern  = regexp.MustCompile("(?s)```.*?```")
+	inlineCodePattern = regexp.MustCompile("(?s)``.+?``|`[^`]+`")
+	urlPattern        = regexp.MustCompile(`https?://\S+`)
+
+	// <@user>, <@!user>, <@&role>, <#channel>, </command:id>, <:emoji:id>,
+	// <a:emoji:id> and <t:timestamp:style>
+	mentionPattern = regexp.MustCompile(`<(?:@[!&]?\d+|#\d+|/[\w -]+:\d+|a?:\w+:\d+|t:-?\d+(?::[tTdDfFR])?)>`)
+
+	// :shortcode: emoji, e.g. custom emoji from servers the author can't use
+	shortcodePattern = regexp.MustCompile(`:[\w~+-]+:`)
+)
+
+// TokenizeMessage splits a Discord message into words like Tokenize, but
+// skips code blocks, inline code, block quotes, URLs, mentions, channel and
+// role links, custom and shortcode emoji, and timestamps. Offsets still refer
+// to the original content.
+func TokenizeMessage(content string) []Token {
+	return Tokenize(MaskMarkdown(content))
+}
+
+// MaskMarkdown replaces every skipped span of a Discord message with spaces,
+// keeping line breaks. The result has the same length as content, so byte
+// offsets are unchanged.
+func MaskMarkdown(content string) string {
+	masked := []byte(content)
+	mask := func(start, end int) {
+		for i := start; i < end; i++ {
+			if masked[i] != '\n' {
+				masked[i] = ' '
+			}
+		}
+	}
+
+	// Code first, since code may contain anything else
+	for _, pattern := range []*regexp.Regexp{codeBlockPattern, inlineCodePattern} {
+		for _, loc := range pattern.FindAllIndex(masked, -1) {
+			mask(loc[0], loc[1])
+		}
+	}
+
+	maskQuotes(masked, mask)
+
+	for _, pattern := range []*regexp.Regexp{urlPattern, mentionPattern, shortcodePattern} {
+		for _, loc := range pattern.FindAllIndex(masked, -1) {
+			mask(loc[0], loc[1])
+		}
+	}
+	return string(masked)
+}
+
+// maskQuotes masks block quotes: a line starting with "> " (or just ">"), and
+// everything after a line starting with ">>> "
+func maskQuotes(masked []byte, mask func(start, end int)) {
+	for lineStart := 0; lineStart < len(masked); {
+		lineEnd := len(masked)
+		if i := bytes.IndexByte(masked[lineStart:], '\n'); i >= 0 {
+			lineEnd = lineStart + i
+		}
+		line := string(masked[lineStart:lineEnd])
+
+		switch {
+		case strings.HasPrefix(line, ">>> "):
+			mask(lineStart, len(masked))
+			return
+		case strings.HasPrefix(line, "> ") || line == ">":
+			mask(lineStart, lineEnd)
+		}
+		lineStart = lineEnd + 1
+	}
+}
@@ -0,0 +1,88 @@
+package bannedwords
+
+import (
+	"strings"
+	"testing"
+
+	"askeladden/internal/database/databasetest"
+)
+
+// tokenTexts returns the token texts of a message joined by spaces
+func tokenTexts(content string) string {
+	var texts []string
+	for _, token := range TokenizeMessage(content) {
+		texts = append(texts, token.Text)
+	}
+	return strings.Join(texts, " ")
+}
+
+func TestTokenizeMessageCorpus(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"plain", "Ikke gjer det", "ikke gjer det"},
+		{"code block", "Sjå her:\n```go\nikke := true\n```\nferdig", "sjå her ferdig"},
+		{"code block on one line", "før ```ikke``` etter", "før etter"},
+		{"unterminated code block", "```ikke", "ikke"},
+		{"inline code", "Skriv `ikke` i staden for `hvordan`", "skriv i staden for"},
+		{"double backtick code", "Bruk ``ikke ` her`` no", "bruk no"},
+		{"quote", "> Ikke sant?\nJau, det sa han", "jau det sa han"},
+		{"bare quote line", ">\nikkje", "ikkje"},
+		{"multi-line quote", "Svar\n>>> ikke\nhvordan", "svar"},
+		{"greater-than is not a quote", ">ikke", "ikke"},
+		{"quote inside a line", "3 > 2 ikke", "3 2 ikke"},
+		{"url", "Les https://example.com/ikke-hvordan?q=ikke no", "les no"},
+		{"suppressed url", "<https://nn.wikipedia.org/wiki/Ikke>", ""},
+		{"masked link keeps text", "[ikke her](https://example.com/hvordan)", "ikke her"},
+		{"user mention", "<@123456> og <@!42> sa ikkje", "og sa ikkje"},
+		{"role and channel", "<@&99> i <#1234>", "i"},
+		{"slash command mention", "Bruk </hvordan ikke:123>", "bruk"},
+		{"custom emoji", "<:ikke:123456> <a:hvordan:42> hei", "hei"},
+		{"shortcode emoji", "Fint :ikke_bra: :+1:", "fint"},
+		{"timestamp", "Møte <t:1700000000:R> og <t:1700000000>", "møte og"},
+		{"clock time is kept", "kl. 10:30 i dag", "kl 10 30 i dag"},
+		{"spoiler is still checked", "||ikke||", "ikke"},
+		{"mixed", "> sitat\n`kode` <@1> ekte ord https://x.no :smil:", "ekte ord"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tokenTexts(tt.content); got != tt.want {
+				t.Errorf("TokenizeMessage(%q) = %q, want %q", tt.content, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenizeMessageKeepsOffsets(t *testing.T) {
+	content := "`kode` Blåbær <@1> ikke"
+	tokens := TokenizeMessage(content)
+	if len(tokens) != 2 {
+		t.Fatalf("tokens = %+v", tokens)
+	}
+	if content[tokens[0].Start:tokens[0].End] != "Blåbær" || content[tokens[1].Start:tokens[1].End] != "ikke" {
+		t.Errorf("offsets do not point into the original content: %+v", tokens)
+	}
+}
+
+func TestMaskMarkdownKeepsLength(t *testing.T) {
+	content := "```\nblåbær\n```\n> æøå <:ø:1>"
+	masked := MaskMarkdown(content)
+	if len(masked) != len(content) || strings.Count(masked, "\n") != strings.Count(content, "\n") {
+		t.Errorf("MaskMarkdown(%q) = %q", content, masked)
+	}
+}
+
+func TestMatcherSkipsMarkdown(t *testing.T) {
+	db := databasetest.New()
+	addApproved(t, db, "ikke")
+	m := NewMatcher(db)
+
+	if found := m.FindAll("> ikke\n`ikke` https://ikke.no <:ikke:1>"); len(found) != 0 {
+		t.Errorf("matched inside skipped spans: %v", found)
+	}
+	if found := m.FindAll("> ikke\nmen ikke her"); len(found) != 1 {
+		t.Errorf("expected a match outside the quote, got %v", found)
+	}
+}
@@ -0,0 +1,234 @@
+// Package bannedwords held ein minnekopi av dei godkjende forbodne orda, slik at
+// kvar melding kan sjekkast utan éin databasespørjing per ord.
+package bannedwords
+
+import (
+	"log"
+	"sync"
+
+	"askeladden/internal/database"
+)
+
+// Source is the part of the database the matcher loads words from
+type Source interface {
+	GetBannedWords() ([]*database.BannedWord, error)
+	GetAllowedPhrases() ([]*database.AllowedPhrase, error)
+}
+
+// Match is one occurrence of a banned word in a message. Start and End are
+// byte offsets into the checked text.
+type Match struct {
+	Word    *database.BannedWord
+	Pattern string
+	Start   int
+	End     int
+}
+
+// entry is a compiled pattern and the banned word it belongs to
+type entry struct {
+	word    *database.BannedWord
+	pattern Pattern
+}
+
+// Matcher is a cached set of active banned words and their patterns.
+// It is safe for concurrent use from the discordgo event goroutines. The
+// cache is loaded lazily and reloaded on the next lookup after Invalidate.
+type Matcher struct {
+	source Source
+
+	mu         sync.RWMutex
+	exact      map[string][]entry  // Patterns keyed by their first word
+	stems      []entry             // Patterns whose first word is a stem
+	exceptions map[int][]Exception // Exceptions keyed by banned word ID
+	allowlist  []Exception         // Exceptions for every word
+	loaded     bool
+	generation int
+}
+
+// NewMatcher creates a matcher backed by source. Nothing is loaded until Load
+// or the first lookup.
+func NewMatcher(source Source) *Matcher {
+	return &Matcher{source: source}
+}
+
+// Load reads the active banned words from the source and replaces the cache
+func (m *Matcher) Load() error {
+	m.mu.RLock()
+	generation := m.generation
+	m.mu.RUnlock()
+
+	bannedWords, err := m.source.GetBannedWords()
+	if err != nil {
+		return err
+	}
+	allowedPhrases, err := m.source.GetAllowedPhrases()
+	if err != nil {
+		return err
+	}
+
+	exact := make(map[string][]entry)
+	var stems []entry
+	exceptions := make(map[int][]Exception)
+	count := 0
+	for _, bw := range bannedWords {
+		if !bw.IsActive() {
+			continue
+		}
+		count++
+
+		for _, source := range bw.Exceptions {
+			exception, err := ParseException(source)
+			if err != nil {
+				log.Printf("Hoppar over unntak for forbode ord '%s': %v", bw.Word, err)
+				continue
+			}
+			exceptions[bw.ID] = append(exceptions[bw.ID], exception)
+		}
+
+		for _, source := range append([]string{bw.Word}, bw.Patterns...) {
+			pattern, err := ParsePattern(source)
+			if err != nil {
+				log.Printf("Hoppar over mønster for forbode ord '%s': %v", bw.Word, err)
+				continue
+			}
+			e := entry{word: bw, pattern: pattern}
+			if first := pattern.parts[0]; first.stem {
+				stems = append(stems, e)
+			} else {
+				exact[first.text] = append(exact[first.text], e)
+			}
+		}
+	}
+
+	var allowlist []Exception
+	for _, phrase := range allowedPhrases {
+		exception, err := ParseException(phrase.Phrase)
+		if err != nil {
+			log.Printf("Hoppar over tillaten frase '%s': %v", phrase.Phrase, err)
+			continue
+		}
+		allowlist = append(allowlist, exception)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.exact = exact
+	m.stems = stems
+	m.exceptions = exceptions
+	m.allowlist = allowlist
+	// If Invalidate ran while we were loading, the result may already be stale
+	m.loaded = m.generation == generation
+	log.Printf("Lasta %d forbodne ord inn i minnet", count)
+	return nil
+}
+
+// Invalidate marks the cache as stale. Call it whenever a banned word is
+// approved, unbanned or gets new patterns or exceptions, and when the
+// allowlist changes.
+func (m *Matcher) Invalidate() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.loaded = false
+	m.generation++
+}
+
+// Lookup returns the banned word matching a single word, ignoring case.
+// The returned value is shared and must not be modified.
+func (m *Matcher) Lookup(word string) (*database.BannedWord, bool) {
+	matches := m.Matches(word)
+	if len(matches) == 1 && matches[0].Start == 0 && matches[0].End == len(word) {
+		return matches[0].Word, true
+	}
+	return nil, false
+}
+
+// Matches returns every occurrence of a banned word or pattern in content.
+// At each position the longest pattern wins and matches never overlap. Code,
+// quotes, links, mentions and emoji are skipped, see TokenizeMessage, and so
+// are matches inside an exception for the word or a phrase on the allowlist.
+func (m *Matcher) Matches(content string) []Match {
+	m.ensureLoaded()
+
+	masked := MaskMarkdown(content)
+	tokens := Tokenize(masked)
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var matches []Match
+	for i := 0; i < len(tokens); {
+		var best entry
+		bestLength := 0
+		try := func(e entry) {
+			if n := e.pattern.matchAt(tokens, i); n > bestLength {
+				best, bestLength = e, n
+			}
+		}
+		for _, e := range m.exact[tokens[i].Text] {
+			try(e)
+		}
+		for _, e := range m.stems {
+			try(e)
+		}
+
+		if bestLength == 0 {
+			i++
+			continue
+		}
+		start, end := tokens[i].Start, tokens[i+bestLength-1].End
+		if !m.excepted(best.word, masked, tokens, start, end) {
+			matches = append(matches, Match{
+				Word:    best.word,
+				Pattern: best.pattern.String(),
+				Start:   start,
+				End:     end,
+			})
+		}
+		i += bestLength
+	}
+	return matches
+}
+
+// excepted reports whether the span start:end is inside an exception for the
+// word or an allowed phrase. The caller must hold the read lock.
+func (m *Matcher) excepted(bw *database.BannedWord, content string, tokens []Token, start, end int) bool {
+	for _, exception := range m.exceptions[bw.ID] {
+		if exception.covers(content, tokens, start, end) {
+			return true
+		}
+	}
+	for _, exception := range m.allowlist {
+		if exception.covers(content, tokens, start, end) {
+			return true
+		}
+	}
+	return false
+}
+
+// FindAll returns the banned words used in content, in order of first use.
+// The returned values are shared and must not be modified.
+func (m *Matcher) FindAll(content string) []*database.BannedWord {
+	var found []*database.BannedWord
+	seen := make(map[int]bool)
+	for _, match := range m.Matches(content) {
+		if !seen[match.Word.ID] {
+			seen[match.Word.ID] = true
+			found = append(found, match.Word)
+		}
+	}
+	return found
+}
+
+// ensureLoaded loads the cache if it is empty or has been invalidated
+func (m *Matcher) ensureLoaded() {
+	m.mu.RLock()
+	loaded := m.loaded
+	m.mu.RUnlock()
+	if loaded {
+		return
+	}
+
+	if err := m.Load(); err != nil {
+		log.Printf("Kunne ikkje laste forbodne ord: %v", err)
+	}
+}
@@ -0,0 +1,139 @@
+package bannedwords
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"askeladden/internal/database"
+	"askeladden/internal/database/databasetest"
+)
+
+// countingSource counts how often the matcher hits the database
+type countingSource struct {
+	Source
+	mu    sync.Mutex
+	loads int
+	err   error
+}
+
+func (c *countingSource) GetBannedWords() ([]*database.BannedWord, error) {
+	c.mu.Lock()
+	c.loads++
+	err := c.err
+	c.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	return c.Source.GetBannedWords()
+}
+
+// addApproved adds a fully approved banned word to the fake database
+func addApproved(t *testing.T, db *databasetest.DB, word string) {
+	t.Helper()
+	id, err := db.AddBannedWordPending(word, "", "u1", "brukar", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.ApproveBannedWordCombined(int(id), []string{"opp"}, []string{"rett"}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFindAllOnlyMatchesApprovedWords(t *testing.T) {
+	db := databasetest.New()
+	addApproved(t, db, "ikke")
+	addApproved(t, db, "hvordan")
+	db.AddBannedWordPending("noen", "", "u1", "brukar", "", "")
+
+	m := NewMatcher(db)
+	found := m.FindAll("Hvordan går det? Jeg vet IKKE, noen ganger ikke.")
+	if len(found) != 2 || found[0].Word != "hvordan" || found[1].Word != "ikke" {
+		t.Fatalf("FindAll = %v, want [hvordan ikke]", words(found))
+	}
+
+	if _, ok := m.Lookup("noen"); ok {
+		t.Error("pending word matched")
+	}
+	if bw, ok := m.Lookup("Ikke"); !ok || bw.Word != "ikke" {
+		t.Error("Lookup should ignore case")
+	}
+}
+
+func TestMatcherCachesUntilInvalidated(t *testing.T) {
+	db := databasetest.New()
+	source := &countingSource{Source: db}
+	m := NewMatcher(source)
+
+	for i := 0; i < 3; i++ {
+		m.FindAll("ikke noe her")
+	}
+	if source.loads != 1 {
+		t.Fatalf("loaded %d times, want 1", source.loads)
+	}
+
+	addApproved(t, db, "ikke")
+	if found := m.FindAll("ikke"); len(found) != 0 {
+		t.Fatal("cache changed without invalidation")
+	}
+
+	m.Invalidate()
+	if found := m.FindAll("ikke"); len(found) != 1 {
+		t.Fatal("new word not found after invalidation")
+	}
+	if source.loads != 2 {
+		t.Errorf("loaded %d times, want 2", source.loads)
+	}
+}
+
+func TestMatcherKeepsWordsWhenReloadFails(t *testing.T) {
+	db := databasetest.New()
+	addApproved(t, db, "ikke")
+	source := &countingSource{Source: db}
+	m := NewMatcher(source)
+	if err := m.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	source.err = errors.New("database is down")
+	m.Invalidate()
+	if found := m.FindAll("ikke"); len(found) != 1 {
+		t.Error("previous words should be used while the database is unavailable")
+	}
+	m.FindAll("ikke")
+	if source.loads != 3 {
+		t.Errorf("loaded %d times, want a retry per lookup while failing", source.loads)
+	}
+}
+
+func TestMatcherConcurrentUse(t *testing.T) {
+	db := databasetest.New()
+	addApproved(t, db, "ikke")
+	m := NewMatcher(db)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if j%10 == 0 {
+					m.Invalidate()
+				}
+				if found := m.FindAll("ikke"); len(found) != 1 {
+					t.Error("word missing during concurrent use")
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func words(found []*database.BannedWord) []string {
+	var result []string
+	for _, bw := range found {
+		result = append(result, bw.Word)
+	}
+	return result
+}
@@ -0,0 +1,84 @@
+package bannedwords
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Pattern is a compiled banned-word pattern: a sequence of words where each
+// word is either matched exactly or, if written with a trailing *, as a stem.
+//
+//	boken          one explicit form
+//	i forhold til  a phrase, matched across whitespace and punctuation
+//	bok*           every word starting with "bok"
+type Pattern struct {
+	source string
+	parts  []patternPart
+}
+
+type patternPart struct {
+	text string
+	stem bool
+}
+
+// ParsePattern validates and compiles a pattern
+func ParsePattern(pattern string) (Pattern, error) {
+	fields := strings.Fields(pattern)
+	if len(fields) == 0 {
+		return Pattern{}, fmt.Errorf("tomt mønster")
+	}
+
+	parts := make([]patternPart, 0, len(fields))
+	for _, field := range fields {
+		stem := strings.HasSuffix(field, "*")
+		text := strings.TrimSuffix(field, "*")
+
+		tokens := Tokenize(text)
+		if len(tokens) != 1 || tokens[0].Start != 0 || tokens[0].End != len(text) {
+			return Pattern{}, fmt.Errorf("«%s» er ikkje eit gyldig ord i mønsteret «%s»", field, pattern)
+		}
+		parts = append(parts, patternPart{text: tokens[0].Text, stem: stem})
+	}
+	return Pattern{source: strings.Join(fields, " "), parts: parts}, nil
+}
+
+// String returns the pattern in its normalised written form
+func (p Pattern) String() string {
+	return p.source
+}
+
+// matchAt reports how many tokens the pattern covers starting at tokens[i],
+// or 0 if it doesn't match there
+func (p Pattern) matchAt(tokens []Token, i int) int {
+	if i+len(p.parts) > len(tokens) {
+		return 0
+	}
+	for j, part := range p.parts {
+		text := tokens[i+j].Text
+		if part.stem {
+			if !strings.HasPrefix(text, part.text) {
+				return 0
+			}
+		} else if text != part.text {
+			return 0
+		}
+	}
+	return len(p.parts)
+}
+
+// ParsePatternList splits a comma- or newline-separated list and validates
+// each pattern. Returns the normalised patterns.
+func ParsePatternList(list string) ([]string, error) {
+	var patterns []string
+	for _, field := range strings.FieldsFunc(list, func(r rune) bool { return r == ',' || r == '\n' }) {
+		if strings.TrimSpace(field) == "" {
+			continue
+		}
+		p, err := ParsePattern(field)
+		if err != nil {
+			return nil, err
+		}
+		patterns = append(patterns, p.String())
+	}
+	return patterns, nil
+}
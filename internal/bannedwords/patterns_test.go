@@ -0,0 +1,116 @@
+package bannedwords
+
+import (
+	"testing"
+
+	"askeladden/internal/database"
+)
+
+// staticSource serves a fixed list of banned words
+type staticSource []*database.BannedWord
+
+func (s staticSource) GetBannedWords() ([]*database.BannedWord, error) {
+	return s, nil
+}
+
+func (s staticSource) GetAllowedPhrases() ([]*database.AllowedPhrase, error) {
+	return nil, nil
+}
+
+func approved(id int, word string, patterns ...string) *database.BannedWord {
+	return &database.BannedWord{ID: id, Word: word, ApprovalStatus: "fully_approved", Patterns: patterns}
+}
+
+func TestTokenizeKeepsNorwegianLettersAndOffsets(t *testing.T) {
+	content := "Blåbærsyltetøy, e-post og «sjå'ru» – 'sitat'!"
+	want := []string{"blåbærsyltetøy", "e-post", "og", "sjå'ru", "sitat"}
+
+	tokens := Tokenize(content)
+	if len(tokens) != len(want) {
+		t.Fatalf("got %d tokens %+v, want %v", len(tokens), tokens, want)
+	}
+	for i, token := range tokens {
+		if token.Text != want[i] {
+			t.Errorf("token %d = %q, want %q", i, token.Text, want[i])
+		}
+		if original := content[token.Start:token.End]; !equalFold(original, want[i]) {
+			t.Errorf("token %d offsets give %q", i, original)
+		}
+	}
+}
+
+func equalFold(a, b string) bool {
+	return Tokenize(a)[0].Text == b
+}
+
+func TestParsePattern(t *testing.T) {
+	valid := map[string]string{
+		"boken":               "boken",
+		"  I   forhold  til ": "I forhold til",
+		"bok*":                "bok*",
+		"på grunn av*":        "på grunn av*",
+	}
+	for input, want := range valid {
+		p, err := ParsePattern(input)
+		if err != nil {
+			t.Errorf("ParsePattern(%q) failed: %v", input, err)
+		} else if p.String() != want {
+			t.Errorf("ParsePattern(%q) = %q, want %q", input, p.String(), want)
+		}
+	}
+
+	for _, input := range []string{"", "*", "bo*k", "*bok", "ord!", "a/b"} {
+		if _, err := ParsePattern(input); err == nil {
+			t.Errorf("ParsePattern(%q) should fail", input)
+		}
+	}
+}
+
+func TestMatchesPhrasesInflectionsAndStems(t *testing.T) {
+	m := NewMatcher(staticSource{
+		approved(1, "forhold", "i forhold til"),
+		approved(2, "boken", "boka", "bøker"),
+		approved(3, "hvordan", "hvorfor*"),
+		approved(4, "ikke"),
+	})
+
+	content := "I forhold til boka: hvorfor ikke? Hvorforen, ikke-boken, forholdet og Bøker."
+	var got []string
+	for _, match := range m.Matches(content) {
+		got = append(got, content[match.Start:match.End])
+	}
+	want := []string{"I forhold til", "boka", "hvorfor", "ikke", "Hvorforen", "Bøker"}
+	if len(got) != len(want) {
+		t.Fatalf("matched %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("match %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestMatchesRespectsWordBoundaries(t *testing.T) {
+	m := NewMatcher(staticSource{approved(1, "bok"), approved(2, "å")})
+
+	for _, content := range []string{"bøkene", "boka", "Åbok", "blåbær", "bokå"} {
+		if matches := m.Matches(content); len(matches) != 0 {
+			t.Errorf("%q matched %+v", content, matches)
+		}
+	}
+	if matches := m.Matches("ei bok å lese"); len(matches) != 2 {
+		t.Errorf("expected bok and å, got %+v", matches)
+	}
+}
+
+func TestLongestPatternWins(t *testing.T) {
+	m := NewMatcher(staticSource{
+		approved(1, "forhold"),
+		approved(2, "i", "i forhold til"),
+	})
+
+	matches := m.Matches("i forhold til")
+	if len(matches) != 1 || matches[0].Word.ID != 2 || matches[0].Pattern != "i forhold til" {
+		t.Fatalf("matches = %+v, want the whole phrase", matches)
+	}
+}
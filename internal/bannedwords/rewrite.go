@@ -0,0 +1,62 @@
+package bannedwords
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Replacement is a banned form found by Rewrite
+type Replacement struct {
+	Match
+	Original    string // The form as written
+	Replacement string // The suggestion in the case of the original, or "" if ambiguous
+}
+
+// Ambiguous reports whether the form was left for the reader to fix: the word
+// has no suggestion or several, or the form is an inflection or phrase that
+// the suggestion for the word doesn't fit as is
+func (r Replacement) Ambiguous() bool {
+	return r.Replacement == ""
+}
+
+// Rewrite replaces every banned form in content with the suggested nynorsk
+// form, using the same matches as Matches. Ambiguous forms are replaced with
+// whatever highlight returns for them. Returns the new text and every form
+// found, in order.
+func (m *Matcher) Rewrite(content string, highlight func(Replacement) string) (string, []Replacement) {
+	var rewritten strings.Builder
+	var replacements []Replacement
+	last := 0
+	for _, match := range m.Matches(content) {
+		r := Replacement{Match: match, Original: content[match.Start:match.End]}
+		if len(match.Word.Suggestions) == 1 && strings.EqualFold(match.Pattern, match.Word.Word) {
+			r.Replacement = matchCase(match.Word.Suggestions[0], r.Original)
+		}
+		replacements = append(replacements, r)
+
+		rewritten.WriteString(content[last:match.Start])
+		if r.Ambiguous() {
+			rewritten.WriteString(highlight(r))
+		} else {
+			rewritten.WriteString(r.Replacement)
+		}
+		last = match.End
+	}
+	rewritten.WriteString(content[last:])
+	return rewritten.String(), replacements
+}
+
+// matchCase writes word in upper case if original is, or capitalised if
+// original starts with a capital letter
+func matchCase(word, original string) string {
+	if utf8.RuneCountInString(original) > 1 && original == strings.ToUpper(original) && original != strings.ToLower(original) {
+		return strings.ToUpper(word)
+	}
+	first, _ := utf8.DecodeRuneInString(original)
+	if !unicode.IsUpper(first) {
+		return word
+	}
+	r, size := utf8.DecodeRuneInString(word)
+	return string(unicode.ToUpper(r)) + word[size:]
+}
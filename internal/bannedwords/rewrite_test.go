@@ -0,0 +1,51 @@
+package bannedwords
+
+import (
+	"testing"
+
+	"askeladden/internal/database"
+)
+
+// suggested is an approved word with suggestions and extra patterns
+func suggested(id int, word string, suggestions []string, patterns ...string) *database.BannedWord {
+	bw := approved(id, word, patterns...)
+	bw.Suggestions = suggestions
+	return bw
+}
+
+func TestRewrite(t *testing.T) {
+	m := NewMatcher(staticSource{
+		suggested(1, "ikke", []string{"ikkje"}),
+		suggested(2, "hvordan", []string{"korleis", "kor"}),
+		suggested(3, "boken", []string{"boka"}, "bøker"),
+		suggested(4, "noen", nil),
+		suggested(5, "jeg", []string{"eg"}),
+	})
+
+	content := "Ikke vet JEG hvordan, men ikke `ikke` noen Bøker."
+	got, replacements := m.Rewrite(content, func(r Replacement) string { return "[" + r.Original + "]" })
+
+	want := "Ikkje vet EG [hvordan], men ikkje `ikke` [noen] [Bøker]."
+	if got != want {
+		t.Errorf("Rewrite = %q, want %q", got, want)
+	}
+
+	var ambiguous []string
+	for _, r := range replacements {
+		if r.Ambiguous() {
+			ambiguous = append(ambiguous, r.Original)
+		}
+	}
+	if len(replacements) != 6 || len(ambiguous) != 3 || ambiguous[0] != "hvordan" || ambiguous[1] != "noen" || ambiguous[2] != "Bøker" {
+		t.Errorf("replacements = %+v", replacements)
+	}
+}
+
+func TestRewriteWithoutBannedWords(t *testing.T) {
+	m := NewMatcher(staticSource{suggested(1, "ikke", []string{"ikkje"})})
+
+	content := "Eg veit ikkje."
+	if got, replacements := m.Rewrite(content, func(r Replacement) string { return "?" }); got != content || len(replacements) != 0 {
+		t.Errorf("Rewrite = %q, %+v", got, replacements)
+	}
+}
@@ -0,0 +1,63 @@
+package bannedwords
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Token is a word in a message together with its byte offsets in the original
+// text. Text is lower-cased; content[Start:End] gives the word as written.
+type Token struct {
+	Text  string
+	Start int
+	End   int
+}
+
+// Tokenize splits content into words. A word is a run of Unicode letters,
+// digits and combining marks, so æ, ø and å never split a word. A hyphen or
+// apostrophe between two letters is kept inside the word (e-post, sjå'ru).
+func Tokenize(content string) []Token {
+	var tokens []Token
+	start := -1
+
+	for i := 0; i < len(content); {
+		r, size := utf8.DecodeRuneInString(content[i:])
+		switch {
+		case isWordRune(r):
+			if start < 0 {
+				start = i
+			}
+		case start >= 0 && isJoiner(r) && i+size < len(content):
+			next, _ := utf8.DecodeRuneInString(content[i+size:])
+			if !isWordRune(next) {
+				tokens = append(tokens, newToken(content, start, i))
+				start = -1
+			}
+		default:
+			if start >= 0 {
+				tokens = append(tokens, newToken(content, start, i))
+				start = -1
+			}
+		}
+		i += size
+	}
+	if start >= 0 {
+		tokens = append(tokens, newToken(content, start, len(content)))
+	}
+	return tokens
+}
+
+func newToken(content string, start, end int) Token {
+	return Token{Text: strings.ToLower(content[start:end]), Start: start, End: end}
+}
+
+// isWordRune reports whether r can be part of a word
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r)
+}
+
+// isJoiner reports whether r may join two parts of one word
+func isJoiner(r rune) bool {
+	return r == '-' || r == '\'' || r == '’'
+}
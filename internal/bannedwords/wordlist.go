@@ -0,0 +1,272 @@
+package bannedwords
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"askeladden/internal/database"
+)
+
+// Supported word list formats
+const (
+	FormatCSV  = "csv"
+	FormatYAML = "yaml"
+)
+
+// ListEntry is one word in an imported or exported word list
+type ListEntry struct {
+	Word        string   `yaml:"word"`
+	Reason      string   `yaml:"reason,omitempty"`
+	Suggestions []string `yaml:"suggestions,omitempty"`
+}
+
+// FormatFromFilename picks the list format from a file extension
+func FormatFromFilename(name string) (string, error) {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv":
+		return FormatCSV, nil
+	case ".yaml", ".yml":
+		return FormatYAML, nil
+	default:
+		return "", fmt.Errorf("ukjent filformat for %q (bruk .csv, .yaml eller .yml)", name)
+	}
+}
+
+// ReadList parses a word list. CSV lists need a header row with the columns
+// word, reason and suggestions, where suggestions are separated by semicolons.
+// YAML lists are a sequence of {word, reason, suggestions} mappings.
+func ReadList(r io.Reader, format string) ([]ListEntry, error) {
+	var entries []ListEntry
+	var err error
+
+	switch format {
+	case FormatCSV:
+		entries, err = readCSV(r)
+	case FormatYAML:
+		err = yaml.NewDecoder(r).Decode(&entries)
+		if err == io.EOF {
+			err = nil
+		}
+	default:
+		err = fmt.Errorf("ukjent format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Normalise and check for empty or repeated words
+	seen := make(map[string]bool)
+	for i := range entries {
+		e := &entries[i]
+		e.Word = strings.ToLower(strings.TrimSpace(e.Word))
+		e.Reason = strings.TrimSpace(e.Reason)
+		e.Suggestions = cleanSuggestions(e.Suggestions)
+
+		if e.Word == "" {
+			return nil, fmt.Errorf("oppføring %d manglar ord", i+1)
+		}
+		if seen[e.Word] {
+			return nil, fmt.Errorf("«%s» står fleire gonger i lista", e.Word)
+		}
+		seen[e.Word] = true
+	}
+	return entries, nil
+}
+
+// readCSV reads a CSV list with a header row
+func readCSV(r io.Reader) ([]ListEntry, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["word"]; !ok {
+		return nil, fmt.Errorf("CSV-fila manglar kolonnen «word»")
+	}
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return record[i]
+		}
+		return ""
+	}
+
+	var entries []ListEntry
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, ListEntry{
+			Word:        field(record, "word"),
+			Reason:      field(record, "reason"),
+			Suggestions: strings.Split(field(record, "suggestions"), ";"),
+		})
+	}
+	return entries, nil
+}
+
+// cleanSuggestions trims suggestions and drops empty ones
+func cleanSuggestions(suggestions []string) []string {
+	var cleaned []string
+	for _, s := range suggestions {
+		if s = strings.Join(strings.Fields(s), " "); s != "" {
+			cleaned = append(cleaned, s)
+		}
+	}
+	return cleaned
+}
+
+// WriteList writes entries in the same format ReadList accepts
+func WriteList(w io.Writer, format string, entries []ListEntry) error {
+	switch format {
+	case FormatCSV:
+		writer := csv.NewWriter(w)
+		writer.Write([]string{"word", "reason", "suggestions"})
+		for _, e := range entries {
+			writer.Write([]string{e.Word, e.Reason, strings.Join(e.Suggestions, ";")})
+		}
+		writer.Flush()
+		return writer.Error()
+	case FormatYAML:
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(entries); err != nil {
+			return err
+		}
+		return encoder.Close()
+	default:
+		return fmt.Errorf("ukjent format %q", format)
+	}
+}
+
+// ListStore is the part of the database used for imports and exports
+type ListStore interface {
+	GetBannedWords() ([]*database.BannedWord, error)
+	ImportBannedWord(word, reason string, suggestions []string, importerID, importerName string) error
+}
+
+// ListChange describes what an import does to one word
+type ListChange struct {
+	Entry   ListEntry
+	Changes []string // Empty for new words
+}
+
+// ListDiff is the result of comparing a word list with the database
+type ListDiff struct {
+	Added     []ListChange
+	Updated   []ListChange
+	Unchanged []ListEntry
+}
+
+// HasChanges reports whether applying the diff would change anything
+func (d *ListDiff) HasChanges() bool {
+	return len(d.Added) > 0 || len(d.Updated) > 0
+}
+
+// String renders the diff with + for new and ~ for changed words
+func (d *ListDiff) String() string {
+	var b strings.Builder
+	for _, c := range d.Added {
+		fmt.Fprintf(&b, "+ %s", c.Entry.Word)
+		if len(c.Entry.Suggestions) > 0 {
+			fmt.Fprintf(&b, " → %s", strings.Join(c.Entry.Suggestions, ", "))
+		}
+		b.WriteString("\n")
+	}
+	for _, c := range d.Updated {
+		fmt.Fprintf(&b, "~ %s: %s\n", c.Entry.Word, strings.Join(c.Changes, ", "))
+	}
+	return b.String()
+}
+
+// ImportList compares entries with the database and, unless dryRun is set,
+// adds or updates every changed word as fully approved by the importer.
+// Words that are in the database but not in the list are left alone.
+func ImportList(store ListStore, entries []ListEntry, importerID, importerName string, dryRun bool) (*ListDiff, error) {
+	existing, err := store.GetBannedWords()
+	if err != nil {
+		return nil, err
+	}
+	byWord := make(map[string]*database.BannedWord, len(existing))
+	for _, bw := range existing {
+		byWord[strings.ToLower(bw.Word)] = bw
+	}
+
+	diff := &ListDiff{}
+	for _, e := range entries {
+		bw, ok := byWord[e.Word]
+		if !ok {
+			diff.Added = append(diff.Added, ListChange{Entry: e})
+			continue
+		}
+
+		var changes []string
+		if bw.ApprovalStatus != "fully_approved" {
+			changes = append(changes, fmt.Sprintf("status %s → fully_approved", bw.ApprovalStatus))
+		} else if bw.UnbannedAt != nil {
+			changes = append(changes, "oppheva → forbode igjen")
+		}
+		if bw.Reason != e.Reason {
+			changes = append(changes, "ny grunn")
+		}
+		if !slices.Equal(bw.Suggestions, e.Suggestions) {
+			changes = append(changes, fmt.Sprintf("forslag [%s] → [%s]", strings.Join(bw.Suggestions, ", "), strings.Join(e.Suggestions, ", ")))
+		}
+		if len(changes) == 0 {
+			diff.Unchanged = append(diff.Unchanged, e)
+		} else {
+			diff.Updated = append(diff.Updated, ListChange{Entry: e, Changes: changes})
+		}
+	}
+
+	if dryRun {
+		return diff, nil
+	}
+	for _, changes := range [][]ListChange{diff.Added, diff.Updated} {
+		for _, c := range changes {
+			if err := store.ImportBannedWord(c.Entry.Word, c.Entry.Reason, c.Entry.Suggestions, importerID, importerName); err != nil {
+				return diff, fmt.Errorf("kunne ikkje importere «%s»: %w", c.Entry.Word, err)
+			}
+		}
+	}
+	return diff, nil
+}
+
+// ExportList returns the active banned words sorted alphabetically
+func ExportList(store ListStore) ([]ListEntry, error) {
+	bannedWords, err := store.GetBannedWords()
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []ListEntry
+	for _, bw := range bannedWords {
+		if !bw.IsActive() {
+			continue
+		}
+		entries = append(entries, ListEntry{Word: bw.Word, Reason: bw.Reason, Suggestions: bw.Suggestions})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Word < entries[j].Word })
+	return entries, nil
+}
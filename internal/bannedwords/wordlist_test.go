@@ -0,0 +1,121 @@
+package bannedwords
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"askeladden/internal/database/databasetest"
+)
+
+const csvList = `word,reason,suggestions
+Ikke,Bokmål,ikkje
+hvordan,,"korleis; kor"
+`
+
+const yamlList = `- word: ikke
+  reason: Bokmål
+  suggestions: [ikkje]
+- word: hvordan
+  suggestions:
+    - korleis
+    - kor
+`
+
+func TestReadListFormatsAgree(t *testing.T) {
+	fromCSV, err := ReadList(strings.NewReader(csvList), FormatCSV)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromYAML, err := ReadList(strings.NewReader(yamlList), FormatYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, entries := range [][]ListEntry{fromCSV, fromYAML} {
+		if len(entries) != 2 || entries[0].Word != "ikke" || entries[0].Reason != "Bokmål" ||
+			strings.Join(entries[1].Suggestions, "|") != "korleis|kor" || entries[1].Reason != "" {
+			t.Errorf("entries = %+v", entries)
+		}
+	}
+}
+
+func TestReadListRejectsBadLists(t *testing.T) {
+	bad := map[string]string{
+		"missing word column": "reason,suggestions\nx,y\n",
+		"empty word":          "word\n\"  \"\n",
+		"duplicate word":      "word\nikke\nIKKE\n",
+	}
+	for name, list := range bad {
+		if _, err := ReadList(strings.NewReader(list), FormatCSV); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestImportListDryRunAndApply(t *testing.T) {
+	db := databasetest.New()
+	pendingID, _ := db.AddBannedWordPending("ikke", "Bokmål", "u1", "brukar", "", "")
+	db.ImportBannedWord("noen", "", []string{"nokon"}, "tidlegare", "tidlegare")
+	entries := []ListEntry{
+		{Word: "ikke", Reason: "Bokmål", Suggestions: []string{"ikkje"}},
+		{Word: "hvordan", Suggestions: []string{"korleis"}},
+		{Word: "noen", Suggestions: []string{"nokon"}},
+	}
+
+	diff, err := ImportList(db, entries, "imp", "Importør", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diff.Added) != 1 || len(diff.Updated) != 1 || len(diff.Unchanged) != 1 {
+		t.Fatalf("diff = %+v", diff)
+	}
+	if got := diff.String(); !strings.Contains(got, "+ hvordan → korleis") || !strings.Contains(got, "~ ikke: status pending → fully_approved") {
+		t.Errorf("diff text:\n%s", got)
+	}
+	if bw, _ := db.GetBannedWordByID(int(pendingID)); bw.ApprovalStatus != "pending" {
+		t.Fatal("dry run changed the database")
+	}
+
+	if _, err := ImportList(db, entries, "imp", "Importør", false); err != nil {
+		t.Fatal(err)
+	}
+	bw, _ := db.GetBannedWordByID(int(pendingID))
+	if bw.ApprovalStatus != "fully_approved" || *bw.OpplysarApprovedBy != "imp" || *bw.RettskrivarApprovedBy != "imp" {
+		t.Errorf("imported word = %+v, want approved by the importer", bw)
+	}
+
+	again, _ := ImportList(db, entries, "imp", "Importør", true)
+	if again.HasChanges() {
+		t.Errorf("second import should be a no-op, got:\n%s", again)
+	}
+}
+
+func TestExportRoundTrip(t *testing.T) {
+	db := databasetest.New()
+	db.ImportBannedWord("ikke", "Bokmål, ikkje nynorsk", []string{"ikkje"}, "imp", "imp")
+	db.ImportBannedWord("hvordan", "", []string{"korleis", "kor"}, "imp", "imp")
+	db.AddBannedWordPending("noen", "", "u1", "brukar", "", "")
+
+	exported, err := ExportList(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(exported) != 2 || exported[0].Word != "hvordan" {
+		t.Fatalf("exported = %+v, want the two approved words sorted", exported)
+	}
+
+	for _, format := range []string{FormatCSV, FormatYAML} {
+		var buf bytes.Buffer
+		if err := WriteList(&buf, format, exported); err != nil {
+			t.Fatal(err)
+		}
+		read, err := ReadList(&buf, format)
+		if err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		if len(read) != 2 || read[1].Reason != "Bokmål, ikkje nynorsk" || strings.Join(read[0].Suggestions, "|") != "korleis|kor" {
+			t.Errorf("%s round trip = %+v", format, read)
+		}
+	}
+}
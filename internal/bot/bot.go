@@ -7,28 +7,39 @@ import (
 
 	"github.com/bwmarrin/discordgo"
 
+	"askeladden/internal/bannedwords"
 	"askeladden/internal/config"
 	"askeladden/internal/database"
+	"askeladden/internal/dictionary"
 )
 
 // Bot represents the main bot structure.
 type Bot struct {
-	Session  *discordgo.Session
-	Config   *config.Config
-	Database *database.DB
+	Session     *discordgo.Session
+	Config      *config.Config
+	Database    database.DatabaseIface
+	BannedWords *bannedwords.Matcher   // Cached approved banned words, invalidate on changes
+	Dictionary  *dictionary.Dictionary // Nynorsk and bokmål word lists, nil if not configured
 }
 
 // New creates a new Bot instance.
-func New(cfg *config.Config, db *database.DB, session *discordgo.Session) *Bot {
+func New(cfg *config.Config, db database.DatabaseIface, session *discordgo.Session) *Bot {
 	return &Bot{
-		Session:  session,
-		Config:   cfg,
-		Database: db,
+		Session:     session,
+		Config:      cfg,
+		Database:    db,
+		BannedWords: bannedwords.NewMatcher(db),
 	}
 }
 
 // Start startar boten og opnar Discord-tilkoplinga.
 func (b *Bot) Start() error {
+	// Load banned words up front so the first message doesn't pay for it
+	if err := b.BannedWords.Load(); err != nil {
+		log.Printf("[BOT] Kunne ikkje laste forbodne ord: %v", err)
+	}
+	b.loadDictionary()
+
 	log.Println("[BOT] Prøver å kople til Discord...")
 	// Open connection
 	err := b.Session.Open()
@@ -42,6 +53,23 @@ func (b *Bot) Start() error {
 	return nil
 }
 
+// loadDictionary lastar ordlistene frå konfigurasjonen, om det er sett opp nokon
+func (b *Bot) loadDictionary() {
+	paths := b.Config.Dictionary
+	if paths.NynorskPath == "" && paths.BokmalPath == "" {
+		return
+	}
+
+	dict, err := dictionary.Load(paths.NynorskPath, paths.BokmalPath)
+	if err != nil {
+		log.Printf("[BOT] Kunne ikkje laste ordlistene: %v", err)
+		return
+	}
+	b.Dictionary = dict
+	nynorsk, bokmal := dict.Size()
+	log.Printf("[BOT] Lasta %d nynorske og %d bokmålske ordformer", nynorsk, bokmal)
+}
+
 // Stop stoppar boten og stenger alle tilkoplingar.
 func (b *Bot) Stop() error {
 	log.Println("[BOT] Askeladden loggar av.")
@@ -56,4 +84,4 @@ func (b *Bot) Stop() error {
 }
 
 // Note: Direct field access is preferred in Go for simplicity
-// Bot fields (Session, Config, Database) are exported for direct access
+// Bot fields (Session, Config, Database, BannedWords, Dictionary) are exported for direct access
@@ -0,0 +1,258 @@
+// Package bottest lagar ein bot med falsk Discord-API og minnedatabase, slik at
+// kommandoar, reaksjonar og handterarar kan testast utan nettverk eller MySQL.
+package bottest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/bwmarrin/discordgo"
+
+	"askeladden/internal/bot"
+	"askeladden/internal/config"
+	"askeladden/internal/database/databasetest"
+)
+
+// IDs used by the default test configuration
+const (
+	GuildID              = "guild"
+	BotUserID            = "askeladden"
+	LogChannelID         = "log"
+	DefaultChannelID     = "kvardagsprat"
+	QueueChannelID       = "sporsmal"
+	RettingChannelID     = "retting"
+	GrammarChannelID     = "grammatikk"
+	StarboardChannelID   = "stjernebrettet"
+	OpplysarRoleID       = "opplysar"
+	RettskrivarRoleID    = "rettskrivar"
+	DefaultStarThreshold = 2
+)
+
+// Request is a REST call the bot made against the fake Discord API
+type Request struct {
+	Method string
+	Path   string
+	Body   []byte
+}
+
+// Decode unmarshals the JSON request body into v
+func (r Request) Decode(v any) error {
+	return json.Unmarshal(r.Body, v)
+}
+
+// Discord is a fake Discord REST API. It records every request and answers
+// with registered responses, falling back to sensible defaults.
+type Discord struct {
+	mu        sync.Mutex
+	responses map[string]any
+	requests  []Request
+	nextID    int
+}
+
+// Respond registers the JSON response for a method and API path, e.g.
+// Respond("GET", "/channels/123/messages/456", &discordgo.Message{...}).
+// A []byte response is returned as-is, which is handy for attachments.
+func (d *Discord) Respond(method, path string, response any) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.responses[method+" "+path] = response
+}
+
+// SetMember registers a guild member with the given roles
+func (d *Discord) SetMember(userID string, roles ...string) {
+	d.Respond("GET", "/guilds/"+GuildID+"/members/"+userID, &discordgo.Member{
+		GuildID: GuildID,
+		User:    &discordgo.User{ID: userID, Username: userID},
+		Roles:   roles,
+	})
+}
+
+// Requests returns recorded requests matching the method and path prefix
+func (d *Discord) Requests(method, pathPrefix string) []Request {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var matched []Request
+	for _, r := range d.requests {
+		if r.Method == method && strings.HasPrefix(r.Path, pathPrefix) {
+			matched = append(matched, r)
+		}
+	}
+	return matched
+}
+
+// SentMessages returns every message posted to a channel. Components are left
+// out, since discordgo can't decode them into the MessageComponent interface.
+func (d *Discord) SentMessages(channelID string) []*discordgo.MessageSend {
+	var messages []*discordgo.MessageSend
+	for _, r := range d.Requests("POST", "/channels/"+channelID+"/messages") {
+		var msg struct {
+			discordgo.MessageSend
+			Components json.RawMessage `json:"components"`
+		}
+		if err := r.Decode(&msg); err == nil {
+			messages = append(messages, &msg.MessageSend)
+		}
+	}
+	return messages
+}
+
+// ChannelEdits returns every edit of a channel or thread
+func (d *Discord) ChannelEdits(channelID string) []*discordgo.ChannelEdit {
+	var edits []*discordgo.ChannelEdit
+	for _, r := range d.Requests("PATCH", "/channels/"+channelID) {
+		if r.Path != "/channels/"+channelID {
+			continue
+		}
+		var edit discordgo.ChannelEdit
+		if err := r.Decode(&edit); err == nil {
+			edits = append(edits, &edit)
+		}
+	}
+	return edits
+}
+
+// InteractionResponses returns the raw bodies of every interaction response
+func (d *Discord) InteractionResponses() []Request {
+	return d.Requests("POST", "/interactions/")
+}
+
+// SentEmbeds returns the embeds of every message posted to a channel
+func (d *Discord) SentEmbeds(channelID string) []*discordgo.MessageEmbed {
+	var embeds []*discordgo.MessageEmbed
+	for _, msg := range d.SentMessages(channelID) {
+		if msg.Embed != nil {
+			embeds = append(embeds, msg.Embed)
+		}
+		embeds = append(embeds, msg.Embeds...)
+	}
+	return embeds
+}
+
+// RoundTrip implements http.RoundTripper
+func (d *Discord) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		body, _ = io.ReadAll(req.Body)
+	}
+	path := strings.TrimPrefix(req.URL.Path, "/api/v"+discordgo.APIVersion)
+
+	d.mu.Lock()
+	d.requests = append(d.requests, Request{Method: req.Method, Path: path, Body: body})
+	response, registered := d.responses[req.Method+" "+path]
+	if !registered {
+		response = d.defaultResponse(req.Method, path)
+	}
+	d.mu.Unlock()
+
+	if response == nil {
+		return jsonResponse(req, http.StatusNotFound, map[string]any{"message": "Unknown " + path, "code": 10000}), nil
+	}
+	if raw, ok := response.([]byte); ok {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     http.StatusText(http.StatusOK),
+			Header:     http.Header{"Content-Type": []string{"application/octet-stream"}},
+			Body:       io.NopCloser(bytes.NewReader(raw)),
+			Request:    req,
+		}, nil
+	}
+	return jsonResponse(req, http.StatusOK, response), nil
+}
+
+// defaultResponse answers the calls most handlers make. Returns nil for 404.
+func (d *Discord) defaultResponse(method, path string) any {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+
+	switch {
+	case method == "GET" && path == "/users/@me":
+		return &discordgo.User{ID: BotUserID, Username: "Askeladden", Bot: true}
+	case method == "GET" && len(parts) == 2 && parts[0] == "users":
+		return &discordgo.User{ID: parts[1], Username: parts[1]}
+	case method == "POST" && path == "/users/@me/channels":
+		d.nextID++
+		return &discordgo.Channel{ID: fmt.Sprintf("dm-%d", d.nextID), Type: discordgo.ChannelTypeDM}
+	case method == "POST" && len(parts) == 3 && parts[0] == "channels" && parts[2] == "messages":
+		d.nextID++
+		return &discordgo.Message{ID: fmt.Sprintf("msg-%d", d.nextID), ChannelID: parts[1]}
+	case method == "POST" && len(parts) == 5 && parts[0] == "channels" && parts[2] == "messages" && parts[4] == "threads":
+		return &discordgo.Channel{ID: "thread-" + parts[3], ParentID: parts[1], Type: discordgo.ChannelTypeGuildPublicThread}
+	case method == "PATCH" && len(parts) == 4 && parts[0] == "channels" && parts[2] == "messages":
+		return &discordgo.Message{ID: parts[3], ChannelID: parts[1]}
+	case method == "POST" && len(parts) == 4 && parts[0] == "interactions" && parts[3] == "callback":
+		return map[string]any{}
+	case method == "PUT" || method == "DELETE":
+		return map[string]any{}
+	}
+	return nil
+}
+
+// jsonResponse builds an HTTP response with a JSON body
+func jsonResponse(req *http.Request, status int, v any) *http.Response {
+	data, _ := json.Marshal(v)
+	return &http.Response{
+		StatusCode: status,
+		Status:     http.StatusText(status),
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(data)),
+		Request:    req,
+	}
+}
+
+// Config returns the configuration used by New
+func Config() *config.Config {
+	cfg := &config.Config{}
+	cfg.Discord.Prefix = "!"
+	cfg.Discord.LogChannelID = LogChannelID
+	cfg.Discord.DefaultChannelID = DefaultChannelID
+	cfg.Approval.QueueChannelID = QueueChannelID
+	cfg.Approval.OpplysarRoleID = OpplysarRoleID
+	cfg.BannedWords.ApprovalChannelID = RettingChannelID
+	cfg.BannedWords.RettskrivarRoleID = RettskrivarRoleID
+	cfg.Grammar.ChannelID = GrammarChannelID
+	cfg.Starboard.ChannelID = StarboardChannelID
+	cfg.Starboard.Threshold = DefaultStarThreshold
+	cfg.Starboard.Emoji = "⭐"
+	cfg.Reactions.Question = "❓"
+	return cfg
+}
+
+// ForumTags returns the status tags of the grammar forum, with the default
+// names and IDs like "tag-godkjent"
+func ForumTags() []discordgo.ForumTag {
+	var tags []discordgo.ForumTag
+	for _, name := range Config().Grammar.Tags.StatusTags() {
+		tags = append(tags, discordgo.ForumTag{ID: "tag-" + name, Name: name})
+	}
+	return tags
+}
+
+// New creates a bot backed by a fake Discord API and an in-memory database.
+// The guild and the configured channels are present in the session state.
+func New() (*bot.Bot, *Discord, *databasetest.DB) {
+	discord := &Discord{responses: make(map[string]any)}
+
+	session, _ := discordgo.New("Bot test")
+	session.Client = &http.Client{Transport: discord}
+	session.State.User = &discordgo.User{ID: BotUserID, Username: "Askeladden", Bot: true}
+	session.State.GuildAdd(&discordgo.Guild{ID: GuildID, Name: "Rørsla"})
+
+	cfg := Config()
+	for _, channelID := range []string{LogChannelID, DefaultChannelID, QueueChannelID, RettingChannelID, GrammarChannelID, StarboardChannelID} {
+		channel := &discordgo.Channel{ID: channelID, GuildID: GuildID, Name: channelID}
+		if channelID == GrammarChannelID {
+			channel.Type = discordgo.ChannelTypeGuildForum
+			channel.AvailableTags = ForumTags()
+		}
+		session.State.ChannelAdd(channel)
+		discord.Respond("GET", "/channels/"+channelID, channel)
+	}
+
+	db := databasetest.New()
+	return bot.New(cfg, db, session), discord, db
+}
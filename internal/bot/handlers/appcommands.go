@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"log"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// reportWordCommandName is the message context-menu command for reporting words
+const reportWordCommandName = "Rapporter feil ord"
+
+// applicationCommands are the slash and context-menu commands the bot registers
+var applicationCommands = []*discordgo.ApplicationCommand{
+	{
+		Name: reportWordCommandName,
+		Type: discordgo.MessageApplicationCommand,
+	},
+	{
+		Name: rewriteCommandName,
+		Type: discordgo.MessageApplicationCommand,
+	},
+}
+
+// registerApplicationCommands registrerer applikasjonskommandoane i kvar guild.
+// Guild-kommandoar er tilgjengelege med ein gong, i motsetnad til globale.
+func registerApplicationCommands(s *discordgo.Session, guilds []*discordgo.Guild) {
+	for _, guild := range guilds {
+		if _, err := s.ApplicationCommandBulkOverwrite(s.State.User.ID, guild.ID, applicationCommands); err != nil {
+			log.Printf("Kunne ikkje registrere applikasjonskommandoar i guild %s: %v", guild.ID, err)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"log"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+
+	"askeladden/internal/bot/services"
+	"askeladden/internal/database"
+)
+
+// MessageUpdate handsamar redigerte meldingar. Åtvaringa følgjer meldinga: ho
+// vert oppdatert når orda endrar seg, sletta når dei er retta, og sendt når
+// nokon redigerer inn eit forbode ord.
+func (h *Handler) MessageUpdate(s *discordgo.Session, m *discordgo.MessageUpdate) {
+	// Updates without an author only add embeds, e.g. link previews
+	if m.Author == nil || s.State.User.ID == m.Author.ID {
+		return
+	}
+
+	warnings, err := h.Bot.Database.GetBannedWordWarningsForMessage(m.ID)
+	if err != nil {
+		return
+	}
+
+	var foundBannedWords []*database.BannedWord
+	if !strings.HasPrefix(m.Content, h.Bot.Config.Discord.Prefix) {
+		foundBannedWords = h.Bot.BannedWords.FindAll(m.Content)
+	}
+
+	if len(warnings) == 0 {
+		if newWords := h.uncountedWords(m.Message, foundBannedWords); len(newWords) > 0 {
+			h.sendBannedWordWarning(s, &discordgo.MessageCreate{Message: m.Message}, newWords)
+		}
+		return
+	}
+
+	if len(foundBannedWords) == 0 {
+		log.Printf("Brukar %s retta melding %s, slettar åtvaringa", m.Author.ID, m.ID)
+		deleteWarningMessages(s, warnings)
+		for _, warning := range warnings {
+			h.Bot.Database.DeleteBannedWordWarning(warning.ID)
+		}
+		return
+	}
+
+	// Words edited into a warned message count like any other use
+	h.uncountedWords(m.Message, foundBannedWords)
+	h.updateWarning(s, m.Message, warnings, foundBannedWords)
+}
+
+// updateWarning lagrar orda som er lagde til eller fjerna, og skriv om åtvaringa
+func (h *Handler) updateWarning(s *discordgo.Session, m *discordgo.Message, warnings []*database.BannedWordWarning, foundBannedWords []*database.BannedWord) {
+	found := make(map[int]bool, len(foundBannedWords))
+	for _, bannedWord := range foundBannedWords {
+		found[bannedWord.ID] = true
+	}
+	warned := make(map[int]bool, len(warnings))
+	changed := false
+
+	for _, warning := range warnings {
+		warned[warning.WordID] = true
+		if !found[warning.WordID] {
+			h.Bot.Database.DeleteBannedWordWarning(warning.ID)
+			changed = true
+		}
+	}
+
+	// New words go on the same warning, so the message keeps one warning
+	first := warnings[0]
+	for _, bannedWord := range foundBannedWords {
+		if warned[bannedWord.ID] {
+			continue
+		}
+		_, err := h.Bot.Database.AddBannedWordWarning(m.Author.ID, bannedWord.ID, m.ChannelID, m.ID, first.WarningChannelID, first.WarningMessageID)
+		if err != nil {
+			log.Printf("Kunne ikkje lagre åtvaring for ord '%s': %v", bannedWord.Word, err)
+		}
+		changed = true
+	}
+
+	if !changed {
+		return
+	}
+
+	warningEmbed := services.CreateBannedWordWarningEmbed(foundBannedWords)
+	if first.WarningChannelID != m.ChannelID {
+		warningEmbed.Fields = append(warningEmbed.Fields, messageLinkField(m.GuildID, m.ChannelID, m.ID))
+	}
+	if _, err := s.ChannelMessageEditEmbed(first.WarningChannelID, first.WarningMessageID, warningEmbed); err != nil {
+		log.Printf("Kunne ikkje oppdatere åtvaring for melding %s: %v", m.ID, err)
+	}
+}
+
+// MessageDelete slettar åtvaringa når meldinga ho gjeld, vert sletta. Sjølve
+// åtvaringa vert ståande i databasen, så nedkjølinga gjeld framleis.
+func (h *Handler) MessageDelete(s *discordgo.Session, m *discordgo.MessageDelete) {
+	warnings, err := h.Bot.Database.GetBannedWordWarningsForMessage(m.ID)
+	if err != nil || len(warnings) == 0 {
+		return
+	}
+	log.Printf("Melding %s vart sletta, slettar åtvaringa", m.ID)
+	deleteWarningMessages(s, warnings)
+}
+
+// deleteWarningMessages slettar kvar åtvaringsmelding éin gong
+func deleteWarningMessages(s *discordgo.Session, warnings []*database.BannedWordWarning) {
+	deleted := make(map[string]bool)
+	for _, warning := range warnings {
+		if deleted[warning.WarningMessageID] {
+			continue
+		}
+		deleted[warning.WarningMessageID] = true
+		if err := s.ChannelMessageDelete(warning.WarningChannelID, warning.WarningMessageID); err != nil {
+			log.Printf("Kunne ikkje slette åtvaring %s: %v", warning.WarningMessageID, err)
+		}
+	}
+}
+
+// uncountedWords returnerer orda som ikkje alt er talde for meldinga, og loggar
+// dei. Ord som er talde då meldinga vart send eller redigert før, skal ikkje
+// telje som gjentaking ein gong til kvar gong meldinga vert redigert.
+func (h *Handler) uncountedWords(m *discordgo.Message, foundBannedWords []*database.BannedWord) []*database.BannedWord {
+	if len(foundBannedWords) == 0 {
+		return nil
+	}
+	countedIDs, err := h.Bot.Database.GetBannedWordHitWordIDs(m.ID)
+	if err != nil {
+		return nil
+	}
+	counted := make(map[int]bool, len(countedIDs))
+	for _, id := range countedIDs {
+		counted[id] = true
+	}
+
+	var newWords []*database.BannedWord
+	for _, bannedWord := range foundBannedWords {
+		if counted[bannedWord.ID] {
+			continue
+		}
+		log.Printf("Oppdaga forbode ord '%s' i redigert melding frå brukar %s", bannedWord.Word, m.Author.ID)
+		h.Bot.Database.AddBannedWordHit(bannedWord.ID, m.Author.ID, m.ChannelID, m.ID)
+		newWords = append(newWords, bannedWord)
+	}
+	return newWords
+}
@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"askeladden/internal/bot/bottest"
+	"askeladden/internal/database"
+)
+
+// editedMessage is an edit of a message from messageFrom
+func editedMessage(messageID, content string) *discordgo.MessageUpdate {
+	return &discordgo.MessageUpdate{Message: messageFrom("skribent", messageID, content).Message}
+}
+
+// warningDeleted reports whether the warning message was deleted
+func warningDeleted(discord *bottest.Discord, channelID, warningID string) bool {
+	return len(discord.Requests("DELETE", "/channels/"+channelID+"/messages/"+warningID)) > 0
+}
+
+func TestEditRemovingWordDeletesWarning(t *testing.T) {
+	b, discord, db := bottest.New()
+	h := New(b)
+	addApprovedWord(db, "ikke")
+
+	h.MessageCreate(b.Session, messageFrom("skribent", "m1", "Det er ikke sant"))
+	h.MessageUpdate(b.Session, editedMessage("m1", "Det er ikkje sant"))
+
+	if !warningDeleted(discord, bottest.DefaultChannelID, "msg-1") {
+		t.Error("warning was not deleted when the word was fixed")
+	}
+	if warnings, _ := db.GetBannedWordWarningsForMessage("m1"); len(warnings) != 0 {
+		t.Errorf("%d warnings left after the fix", len(warnings))
+	}
+
+	// Fixed words don't count towards the cooldown
+	h.MessageCreate(b.Session, messageFrom("skribent", "m2", "ikke"))
+	if n := warningsPosted(discord); n != 2 {
+		t.Errorf("posted %d warnings, want 2", n)
+	}
+}
+
+func TestEditChangingWordsUpdatesWarning(t *testing.T) {
+	b, discord, db := bottest.New()
+	h := New(b)
+	addApprovedWord(db, "ikke")
+	addApprovedWord(db, "hvordan")
+
+	h.MessageCreate(b.Session, messageFrom("skribent", "m1", "ikke"))
+	h.MessageUpdate(b.Session, editedMessage("m1", "hvordan"))
+
+	edits := discord.Requests("PATCH", "/channels/"+bottest.DefaultChannelID+"/messages/msg-1")
+	if len(edits) != 1 {
+		t.Fatalf("warning edited %d times, want 1", len(edits))
+	}
+	var edit discordgo.MessageEdit
+	edits[0].Decode(&edit)
+	if edit.Embeds == nil || len(*edit.Embeds) != 1 {
+		t.Fatalf("edit = %+v", edit)
+	}
+	if text := (*edit.Embeds)[0].Description; !strings.Contains(text, "hvordan") || strings.Contains(text, "ikke") {
+		t.Errorf("edited warning = %q, want only hvordan", text)
+	}
+
+	warnings, _ := db.GetBannedWordWarningsForMessage("m1")
+	if len(warnings) != 1 || warnings[0].WordID != 2 || warnings[0].WarningMessageID != "msg-1" {
+		t.Errorf("warnings = %+v, want hvordan on the same warning", warnings)
+	}
+
+	// An edit that leaves the words alone doesn't touch the warning
+	h.MessageUpdate(b.Session, editedMessage("m1", "hvordan da"))
+	if n := len(discord.Requests("PATCH", "/channels/")); n != 1 {
+		t.Errorf("warning edited %d times, want 1", n)
+	}
+	if n := warningsPosted(discord); n != 1 {
+		t.Errorf("posted %d warnings, want 1", n)
+	}
+}
+
+func TestEditAddingWordWarns(t *testing.T) {
+	b, discord, db := bottest.New()
+	h := New(b)
+	addApprovedWord(db, "ikke")
+
+	h.MessageCreate(b.Session, messageFrom("skribent", "m1", "Det er sant"))
+	h.MessageUpdate(b.Session, editedMessage("m1", "Det er ikke sant"))
+
+	if n := warningsPosted(discord); n != 1 {
+		t.Fatalf("posted %d warnings, want 1", n)
+	}
+	if warnings, _ := db.GetBannedWordWarningsForMessage("m1"); len(warnings) != 1 {
+		t.Errorf("%d warnings recorded for the edited message, want 1", len(warnings))
+	}
+
+	// Updates without an author are embed updates and carry no content
+	h.MessageUpdate(b.Session, &discordgo.MessageUpdate{Message: &discordgo.Message{ID: "m1", ChannelID: bottest.DefaultChannelID}})
+	if warningDeleted(discord, bottest.DefaultChannelID, "msg-1") {
+		t.Error("embed update deleted the warning")
+	}
+}
+
+func TestEditAddingWordToWarnedMessageCountsHit(t *testing.T) {
+	b, _, db := bottest.New()
+	h := New(b)
+	addApprovedWord(db, "ikke")
+	addApprovedWord(db, "hvordan")
+
+	h.MessageCreate(b.Session, messageFrom("skribent", "m1", "ikke"))
+	h.MessageUpdate(b.Session, editedMessage("m1", "ikke hvordan"))
+	h.MessageUpdate(b.Session, editedMessage("m1", "ikke hvordan da"))
+
+	if ids, _ := db.GetBannedWordHitWordIDs("m1"); len(ids) != 2 {
+		t.Errorf("hit word IDs = %v, want both words", ids)
+	}
+	if n, _ := db.CountBannedWordHits("skribent", time.Time{}, time.Now().Add(time.Hour)); n != 2 {
+		t.Errorf("%d hits counted, want 2", n)
+	}
+}
+
+func TestEditOfRepeatIsNotCountedAgain(t *testing.T) {
+	b, discord, db := bottest.New()
+	h := New(b)
+	addApprovedWord(db, "ikke")
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	db.Now = func() time.Time { return now }
+
+	h.MessageCreate(b.Session, messageFrom("skribent", "m1", "ikke"))
+	h.MessageCreate(b.Session, messageFrom("skribent", "m2", "ikke"))
+
+	h.MessageUpdate(b.Session, editedMessage("m2", "ikke no"))
+	h.MessageUpdate(b.Session, editedMessage("m2", "ikke no då"))
+
+	if w, _ := db.GetRecentBannedWordWarning("skribent", 1, time.Minute); w == nil || w.HitCount != 2 {
+		t.Errorf("warning = %+v, want two hits", w)
+	}
+	if n := warningsPosted(discord); n != 1 {
+		t.Errorf("posted %d warnings, want 1", n)
+	}
+}
+
+func TestEditOfRepeatInDMModeIsNotCountedAgain(t *testing.T) {
+	b, _, db := bottest.New()
+	h := New(b)
+	addApprovedWord(db, "ikke")
+	addApprovedWord(db, "hvordan")
+	db.SetUserWarningMode("skribent", database.WarningModeDM)
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	db.Now = func() time.Time { return now }
+
+	h.MessageCreate(b.Session, messageFrom("skribent", "m1", "ikke"))
+	h.MessageCreate(b.Session, messageFrom("skribent", "m2", "ikke"))
+	for _, content := range []string{"ikke no", "ikke no då", "ikke no då, ja"} {
+		h.MessageUpdate(b.Session, editedMessage("m2", content))
+	}
+
+	if w, _ := db.GetRecentBannedWordWarning("skribent", 1, time.Minute); w == nil || w.HitCount != 2 {
+		t.Errorf("warning = %+v, want two hits", w)
+	}
+
+	// A word edited in later is new for the message and is counted once
+	h.MessageCreate(b.Session, messageFrom("skribent", "m3", "hvordan"))
+	h.MessageUpdate(b.Session, editedMessage("m2", "ikke no, hvordan"))
+	h.MessageUpdate(b.Session, editedMessage("m2", "ikke no, hvordan då"))
+	if w, _ := db.GetRecentBannedWordWarning("skribent", 2, time.Minute); w == nil || w.HitCount != 2 {
+		t.Errorf("warning = %+v, want two hits", w)
+	}
+}
+
+func TestDeleteRemovesWarning(t *testing.T) {
+	b, discord, db := bottest.New()
+	h := New(b)
+	addApprovedWord(db, "ikke")
+
+	h.MessageCreate(b.Session, messageFrom("skribent", "m1", "ikke"))
+	h.MessageDelete(b.Session, &discordgo.MessageDelete{Message: &discordgo.Message{ID: "m1", ChannelID: bottest.DefaultChannelID}})
+
+	if !warningDeleted(discord, bottest.DefaultChannelID, "msg-1") {
+		t.Error("warning was not deleted with its message")
+	}
+
+	// Deleting the message doesn't dodge the cooldown
+	h.MessageCreate(b.Session, messageFrom("skribent", "m2", "ikke"))
+	if n := warningsPosted(discord); n != 1 {
+		t.Errorf("posted %d warnings, want 1", n)
+	}
+
+	// Messages without a warning are ignored
+	h.MessageDelete(b.Session, &discordgo.MessageDelete{Message: &discordgo.Message{ID: "m3", ChannelID: bottest.DefaultChannelID}})
+	if n := len(discord.Requests("DELETE", "/channels/")); n != 1 {
+		t.Errorf("%d deletes, want 1", n)
+	}
+}
+
+func TestEditUpdatesDMWarning(t *testing.T) {
+	b, discord, db := bottest.New()
+	h := New(b)
+	addApprovedWord(db, "ikke")
+	addApprovedWord(db, "hvordan")
+	db.SetUserWarningMode("skribent", database.WarningModeDM)
+
+	h.MessageCreate(b.Session, messageFrom("skribent", "m1", "ikke"))
+	h.MessageUpdate(b.Session, editedMessage("m1", "ikke hvordan"))
+
+	edits := discord.Requests("PATCH", "/channels/dm-1/messages/msg-2")
+	if len(edits) != 1 {
+		t.Fatalf("DM warning edited %d times, want 1", len(edits))
+	}
+	var edit discordgo.MessageEdit
+	edits[0].Decode(&edit)
+	fields := (*edit.Embeds)[0].Fields
+	if !strings.Contains(fields[len(fields)-1].Value, "/m1)") {
+		t.Errorf("edited DM warning lost the message link: %+v", fields)
+	}
+}
@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"log"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+
+	"askeladden/internal/commands"
+	"askeladden/internal/reactions"
+)
+
+// InteractionCreate handsamar knappeklikk og andre interaksjonar
+func (h *Handler) InteractionCreate(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if i.Type == discordgo.InteractionMessageComponent {
+		customID := i.MessageComponentData().CustomID
+
+		if strings.HasPrefix(customID, reportWordButtonPrefix) {
+			h.handleReportWordButton(s, i)
+			return
+		}
+
+		if strings.HasPrefix(customID, reactions.RejectReasonButtonPrefix) {
+			h.handleRejectReasonButton(s, i)
+			return
+		}
+
+		if strings.HasPrefix(customID, commands.WordListButtonPrefix) {
+			h.handleWordListButton(s, i)
+			return
+		}
+
+		if customID == "confirm_clear_database" {
+			// Check if the user is an admin
+			if !h.Services.Approval.UserHasOpplysarRole(s, i.GuildID, i.Member.User.ID) {
+				// Respond to the interaction with an error message
+				err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+					Type: discordgo.InteractionResponseChannelMessageWithSource,
+					Data: &discordgo.InteractionResponseData{
+						Content: "Du har ikkje tilgang til å tømme databasen.",
+						Flags:   discordgo.MessageFlagsEphemeral,
+					},
+				})
+				if err != nil {
+					log.Printf("Kunne ikkje sende interaksjons-svar: %v", err)
+				}
+				return
+			}
+
+			// Clear the database
+			if err := h.Bot.Database.ClearDatabase(); err != nil {
+				log.Printf("Kunne ikkje tømme database: %v", err)
+				// Let the user know something went wrong
+				s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+					Type: discordgo.InteractionResponseChannelMessageWithSource,
+					Data: &discordgo.InteractionResponseData{
+						Content: "Ein feil oppstod under tømming av databasen.",
+						Flags:   discordgo.MessageFlagsEphemeral,
+					},
+				})
+				return
+			}
+
+			// Respond to the interaction
+			err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseChannelMessageWithSource,
+				Data: &discordgo.InteractionResponseData{
+					Content: "✅ Databasen har blitt tømt.",
+				},
+			})
+			if err != nil {
+				log.Printf("Kunne ikkje sende interaksjons-svar: %v", err)
+			}
+
+			// Delete the original confirmation message
+			s.ChannelMessageDelete(i.ChannelID, i.Message.ID)
+		}
+	} else if i.Type == discordgo.InteractionApplicationCommand {
+		switch i.ApplicationCommandData().Name {
+		case reportWordCommandName:
+			h.handleReportWordCommand(s, i)
+		case rewriteCommandName:
+			h.handleRewriteCommand(s, i)
+		}
+	} else if i.Type == discordgo.InteractionModalSubmit {
+		customID := i.ModalSubmitData().CustomID
+
+		if strings.HasPrefix(customID, reportWordModalPrefix) {
+			h.handleReportWordSubmit(s, i)
+		} else if strings.HasPrefix(customID, rejectReasonModalPrefix) {
+			h.handleRejectReasonSubmit(s, i)
+		}
+	}
+}
+
+// interactionUserID returns the user behind an interaction in a guild or DM
+func interactionUserID(i *discordgo.InteractionCreate) string {
+	if i.Member != nil && i.Member.User != nil {
+		return i.Member.User.ID
+	}
+	if i.User != nil {
+		return i.User.ID
+	}
+	return ""
+}
+
+// interactionUsername returns the username behind an interaction in a guild or DM
+func interactionUsername(i *discordgo.InteractionCreate) string {
+	if i.Member != nil && i.Member.User != nil {
+		return i.Member.User.Username
+	}
+	if i.User != nil {
+		return i.User.Username
+	}
+	return ""
+}
+
+// modalTextValue returns the value of a text input in a submitted modal
+func modalTextValue(data discordgo.ModalSubmitInteractionData, customID string) string {
+	for _, row := range data.Components {
+		actionsRow, ok := row.(*discordgo.ActionsRow)
+		if !ok {
+			continue
+		}
+		for _, component := range actionsRow.Components {
+			if input, ok := component.(*discordgo.TextInput); ok && input.CustomID == customID {
+				return input.Value
+			}
+		}
+	}
+	return ""
+}
+
+// respondEphemeral svarar på ein interaksjon med ei melding berre brukaren ser
+func respondEphemeral(s *discordgo.Session, i *discordgo.InteractionCreate, content string) {
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		log.Printf("Kunne ikkje sende interaksjons-svar: %v", err)
+	}
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+
+	"askeladden/internal/bot/services"
+	"askeladden/internal/database"
+	"askeladden/internal/reactions"
+)
+
+// rejectReasonModalPrefix starts the custom ID of the rejection reason modal
+const rejectReasonModalPrefix = "reject_reason_modal:"
+
+// handleRejectReasonButton opnar eit skjema der den som avviste eit ord kan skrive grunngiving
+func (h *Handler) handleRejectReasonButton(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	bannedWord := h.rejectedWordForInteraction(s, i, strings.TrimPrefix(i.MessageComponentData().CustomID, reactions.RejectReasonButtonPrefix))
+	if bannedWord == nil {
+		return
+	}
+
+	reason := ""
+	if bannedWord.RejectionReason != nil {
+		reason = *bannedWord.RejectionReason
+	}
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseModal,
+		Data: &discordgo.InteractionResponseData{
+			CustomID: fmt.Sprintf("%s%d", rejectReasonModalPrefix, bannedWord.ID),
+			Title:    "Kvifor vart ordet avvist?",
+			Components: []discordgo.MessageComponent{
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						discordgo.TextInput{
+							CustomID:    "reason",
+							Label:       fmt.Sprintf("Grunngiving for «%s»", bannedWord.Word),
+							Style:       discordgo.TextInputParagraph,
+							Placeholder: "T.d. ordet er godkjent nynorsk etter 2012-rettskrivinga.",
+							Value:       reason,
+							Required:    true,
+							MaxLength:   500,
+						},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		log.Printf("Kunne ikkje opne grunngivingsskjema: %v", err)
+	}
+}
+
+// handleRejectReasonSubmit lagrar grunngivinga, oppdaterer meldinga og sender
+// grunngivinga til melderen, som alt har fått vite at ordet vart avvist
+func (h *Handler) handleRejectReasonSubmit(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	data := i.ModalSubmitData()
+	bannedWord := h.rejectedWordForInteraction(s, i, strings.TrimPrefix(data.CustomID, rejectReasonModalPrefix))
+	if bannedWord == nil {
+		return
+	}
+
+	reason := strings.TrimSpace(modalTextValue(data, "reason"))
+	if reason == "" {
+		respondEphemeral(s, i, "Grunngivinga kan ikkje vere tom.")
+		return
+	}
+
+	if err := h.Bot.Database.UpdateBannedWordRejectionReason(bannedWord.ID, reason); err != nil {
+		log.Printf("Kunne ikkje lagre grunngiving for ord %d: %v", bannedWord.ID, err)
+		respondEphemeral(s, i, "Kunne ikkje lagre grunngivinga.")
+		return
+	}
+	bannedWord.RejectionReason = &reason
+
+	// Replace the embed and drop the button on the retting message
+	reporter, _ := s.User(bannedWord.AuthorID)
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{services.CreateBannedWordRejectionEmbed(bannedWord, reporter)},
+			Components: []discordgo.MessageComponent{},
+		},
+	})
+	if err != nil {
+		log.Printf("Kunne ikkje oppdatere avvisingsmelding: %v", err)
+	}
+
+	h.Services.Approval.NotifyBannedWordRejectionReason(s, bannedWord)
+}
+
+// rejectedWordForInteraction finn det avviste ordet og sjekkar at brukaren er den som avviste det.
+// Svarar brukaren og returnerer nil om noko er gale.
+func (h *Handler) rejectedWordForInteraction(s *discordgo.Session, i *discordgo.InteractionCreate, idText string) *database.BannedWord {
+	wordID, err := strconv.Atoi(idText)
+	if err != nil {
+		respondEphemeral(s, i, "Ugyldig ord-ID.")
+		return nil
+	}
+
+	bannedWord, err := h.Bot.Database.GetBannedWordByID(wordID)
+	if err != nil || bannedWord.ApprovalStatus != "rejected" {
+		respondEphemeral(s, i, "Fann ikkje noko avvist ord å grunngje.")
+		return nil
+	}
+
+	if bannedWord.RejectedBy == nil || *bannedWord.RejectedBy != interactionUserID(i) {
+		respondEphemeral(s, i, "Berre den som avviste ordet kan leggje til grunngiving.")
+		return nil
+	}
+	return bannedWord
+}
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+
+	"askeladden/internal/bot/bottest"
+	"askeladden/internal/reactions"
+)
+
+// interactionResponse is the part of an interaction response the tests look at
+type interactionResponse struct {
+	Type discordgo.InteractionResponseType `json:"type"`
+	Data struct {
+		CustomID   string                    `json:"custom_id"`
+		Content    string                    `json:"content"`
+		Embeds     []*discordgo.MessageEmbed `json:"embeds"`
+		Components []json.RawMessage         `json:"components"`
+	} `json:"data"`
+}
+
+func lastInteractionResponse(t *testing.T, discord *bottest.Discord) interactionResponse {
+	t.Helper()
+	responses := discord.InteractionResponses()
+	if len(responses) == 0 {
+		t.Fatal("no interaction response")
+	}
+	var response interactionResponse
+	if err := responses[len(responses)-1].Decode(&response); err != nil {
+		t.Fatal(err)
+	}
+	return response
+}
+
+func newInteraction(userID string, data discordgo.InteractionData, interactionType discordgo.InteractionType) *discordgo.InteractionCreate {
+	return &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+		ID:        "interaction",
+		Token:     "token",
+		Type:      interactionType,
+		GuildID:   bottest.GuildID,
+		ChannelID: bottest.RettingChannelID,
+		Member:    &discordgo.Member{User: &discordgo.User{ID: userID}},
+		Message:   &discordgo.Message{ID: "bw-msg", ChannelID: bottest.RettingChannelID},
+		Data:      data,
+	}}
+}
+
+func TestRejectionReasonFlow(t *testing.T) {
+	b, discord, db := bottest.New()
+	h := New(b)
+	id, _ := db.AddBannedWordPending("ikkje", "", "melder", "melder", "", "")
+	db.RejectBannedWord(int(id), "rett")
+
+	button := discordgo.MessageComponentInteractionData{CustomID: fmt.Sprintf("%s%d", reactions.RejectReasonButtonPrefix, id)}
+
+	// Someone else can't add the reason
+	h.InteractionCreate(b.Session, newInteraction("annan", button, discordgo.InteractionMessageComponent))
+	if response := lastInteractionResponse(t, discord); response.Type != discordgo.InteractionResponseChannelMessageWithSource {
+		t.Fatalf("expected an ephemeral refusal, got %+v", response)
+	}
+
+	h.InteractionCreate(b.Session, newInteraction("rett", button, discordgo.InteractionMessageComponent))
+	modal := lastInteractionResponse(t, discord)
+	if modal.Type != discordgo.InteractionResponseModal || modal.Data.CustomID != fmt.Sprintf("%s%d", rejectReasonModalPrefix, id) {
+		t.Fatalf("expected the reason modal, got %+v", modal)
+	}
+
+	submit := discordgo.ModalSubmitInteractionData{
+		CustomID: modal.Data.CustomID,
+		Components: []discordgo.MessageComponent{
+			&discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+				&discordgo.TextInput{CustomID: "reason", Value: "  Godkjent form sidan 2012. "},
+			}},
+		},
+	}
+	h.InteractionCreate(b.Session, newInteraction("rett", submit, discordgo.InteractionModalSubmit))
+
+	bw, _ := db.GetBannedWordByID(int(id))
+	if bw.RejectionReason == nil || *bw.RejectionReason != "Godkjent form sidan 2012." {
+		t.Fatalf("rejection reason = %v", bw.RejectionReason)
+	}
+
+	update := lastInteractionResponse(t, discord)
+	if update.Type != discordgo.InteractionResponseUpdateMessage || len(update.Data.Components) != 0 ||
+		!strings.Contains(update.Data.Embeds[0].Description, "Godkjent form sidan 2012.") {
+		t.Errorf("message update = %+v", update)
+	}
+
+	dms := discord.Requests("POST", "/users/@me/channels")
+	if len(dms) != 1 {
+		t.Fatalf("expected the reporter to get the reason by DM, got %d DM channels", len(dms))
+	}
+	embeds := discord.SentEmbeds("dm-1")
+	if len(embeds) != 1 || embeds[0].Title != "📝 Grunngiving" || !strings.Contains(embeds[0].Description, "Godkjent form sidan 2012.") {
+		t.Errorf("DM embeds = %+v", embeds)
+	}
+}
+
+func TestRejectionWithReasonSendsOneRejectionDM(t *testing.T) {
+	b, discord, db := bottest.New()
+	h := New(b)
+	reactions.InitializeReactions(b)
+	id, _ := db.AddBannedWordPending("ikkje", "", "melder", "melder", "", "c|m")
+	db.UpdateBannedWordApprovalMessageID(int(id), "bw-msg")
+	discord.SetMember("rett", bottest.RettskrivarRoleID)
+
+	reactions.MatchAndRunReaction("👎", b.Session, &discordgo.MessageReactionAdd{MessageReaction: &discordgo.MessageReaction{
+		UserID: "rett", MessageID: "bw-msg", ChannelID: bottest.RettingChannelID, GuildID: bottest.GuildID, Emoji: discordgo.Emoji{Name: "👎"},
+	}}, b)
+	submit := discordgo.ModalSubmitInteractionData{
+		CustomID: fmt.Sprintf("%s%d", rejectReasonModalPrefix, id),
+		Components: []discordgo.MessageComponent{
+			&discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+				&discordgo.TextInput{CustomID: "reason", Value: "Godkjent form."},
+			}},
+		},
+	}
+	h.InteractionCreate(b.Session, newInteraction("rett", submit, discordgo.InteractionModalSubmit))
+
+	var rejections, reasons int
+	for _, r := range discord.Requests("POST", "/channels/dm-") {
+		var msg discordgo.MessageSend
+		r.Decode(&msg)
+		for _, embed := range msg.Embeds {
+			switch embed.Title {
+			case "❌ Ordrapport avvist":
+				rejections++
+			case "📝 Grunngiving":
+				reasons++
+			}
+		}
+	}
+	if rejections != 1 || reasons != 1 {
+		t.Errorf("got %d rejection DMs and %d reason DMs, want one of each", rejections, reasons)
+	}
+}
@@ -0,0 +1,255 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"askeladden/internal/bot/services"
+)
+
+// Custom IDs for the report flow. The button is followed by
+// "<channel ID>:<message ID>" of the hammered message, and the modal by
+// "<source>:<channel ID>:<message ID>".
+const (
+	reportWordButtonPrefix = "report_word:"
+	reportWordModalPrefix  = "report_word_modal:"
+)
+
+// Where a report came from, as given in the modal custom ID
+const (
+	reportSourceHammer = "hammer"
+	reportSourceMenu   = "meny"
+)
+
+// reportReasons is the reason stored on a reported word for each source
+var reportReasons = map[string]string{
+	reportSourceHammer: "Rapportert med 🔨",
+	reportSourceMenu:   "Rapportert frå kontekstmenyen",
+}
+
+// reportPromptTimeout is how long an unused report prompt stays in the channel
+var reportPromptTimeout = 2 * time.Minute
+
+// promptForIncorrectWord svarar på 🔨 med ein knapp som opnar rapportskjemaet.
+// Hammaren vert fjerna med ein gong, og knappen når han er brukt eller etter
+// reportPromptTimeout, så rapporten ikkje vert ståande synleg i kanalen.
+func (h *Handler) promptForIncorrectWord(s *discordgo.Session, r *discordgo.MessageReactionAdd) {
+	log.Printf("Brukar %s rapporterte feil ord i melding %s", r.UserID, r.MessageID)
+	_, err := s.ChannelMessage(r.ChannelID, r.MessageID)
+	if err != nil {
+		log.Printf("Feil ved henting av melding: %v", err)
+		return
+	}
+
+	if err := s.MessageReactionRemove(r.ChannelID, r.MessageID, r.Emoji.APIName(), r.UserID); err != nil {
+		log.Printf("Kunne ikkje fjerne 🔨 frå melding %s: %v", r.MessageID, err)
+	}
+
+	promptEmbed := services.NewEmbedBuilder().
+		SetTitle("🚨 Rapporter feil ord").
+		SetDescription("Trykk på knappen og skriv inn orda som er feil.").
+		SetColorByType(services.EmbedTypeError).
+		Build()
+
+	prompt, err := s.ChannelMessageSendComplex(r.ChannelID, &discordgo.MessageSend{
+		Embeds: []*discordgo.MessageEmbed{promptEmbed},
+		Components: []discordgo.MessageComponent{
+			discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{
+					discordgo.Button{
+						Label:    "Rapporter ord",
+						Emoji:    &discordgo.ComponentEmoji{Name: "🔨"},
+						Style:    discordgo.DangerButton,
+						CustomID: reportWordButtonPrefix + r.ChannelID + ":" + r.MessageID,
+					},
+				},
+			},
+		},
+		Reference: &discordgo.MessageReference{
+			MessageID: r.MessageID,
+			ChannelID: r.ChannelID,
+			GuildID:   r.GuildID,
+		},
+	})
+	if err != nil {
+		log.Printf("Kunne ikkje sende rapportknapp: %v", err)
+		return
+	}
+
+	// A used prompt is already deleted when the report is submitted, so a
+	// failed delete here is expected and not logged
+	time.AfterFunc(reportPromptTimeout, func() {
+		s.ChannelMessageDelete(prompt.ChannelID, prompt.ID)
+	})
+}
+
+// handleReportWordButton opnar skjemaet der brukaren skriv inn orda som er feil
+func (h *Handler) handleReportWordButton(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	channelID, messageID, ok := parseReportTarget(strings.TrimPrefix(i.MessageComponentData().CustomID, reportWordButtonPrefix))
+	if !ok {
+		respondEphemeral(s, i, "Ugyldig rapportknapp.")
+		return
+	}
+	respondWithReportModal(s, i, reportSourceHammer, channelID, messageID)
+}
+
+// handleReportWordCommand opnar rapportskjemaet frå kontekstmenyen på ei melding
+func (h *Handler) handleReportWordCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	log.Printf("Brukar %s rapporterte feil ord i melding %s via kontekstmenyen", interactionUserID(i), i.ApplicationCommandData().TargetID)
+	respondWithReportModal(s, i, reportSourceMenu, i.ChannelID, i.ApplicationCommandData().TargetID)
+}
+
+// respondWithReportModal svarar på ein interaksjon med skjemaet for å rapportere feil ord i ei melding
+func respondWithReportModal(s *discordgo.Session, i *discordgo.InteractionCreate, source, channelID, messageID string) {
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseModal,
+		Data: &discordgo.InteractionResponseData{
+			CustomID: reportWordModalPrefix + source + ":" + channelID + ":" + messageID,
+			Title:    "Rapporter feil ord",
+			Components: []discordgo.MessageComponent{
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						discordgo.TextInput{
+							CustomID:    "words",
+							Label:       "Ord som er feil (skilde med komma)",
+							Style:       discordgo.TextInputShort,
+							Placeholder: "T.d. ikke, hvordan",
+							Required:    true,
+							MaxLength:   200,
+						},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		log.Printf("Kunne ikkje opne rapportskjema: %v", err)
+	}
+}
+
+// handleReportWordSubmit legg til dei rapporterte orda og fjernar rapportknappen
+func (h *Handler) handleReportWordSubmit(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	data := i.ModalSubmitData()
+	source, target, _ := strings.Cut(strings.TrimPrefix(data.CustomID, reportWordModalPrefix), ":")
+	originalChannelID, originalMessageID, ok := parseReportTarget(target)
+	if _, known := reportReasons[source]; !known || !ok {
+		respondEphemeral(s, i, "Ugyldig rapportskjema.")
+		return
+	}
+
+	var validWords []string
+	for _, word := range strings.Split(modalTextValue(data, "words"), ",") {
+		if word = strings.TrimSpace(word); word != "" {
+			validWords = append(validWords, word)
+		}
+	}
+	if len(validWords) == 0 {
+		respondEphemeral(s, i, "Du må skrive inn minst eitt ord.")
+		return
+	}
+
+	confirmText := h.processIncorrectWordReport(validWords, source, interactionUserID(i), interactionUsername(i), i.GuildID, originalChannelID, originalMessageID)
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{services.CreateSuccessEmbed("Ord rapporterte", confirmText)},
+			Flags:  discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		log.Printf("Kunne ikkje sende interaksjons-svar: %v", err)
+	}
+
+	// The prompt has done its job, so don't leave it in the channel. Reports
+	// from the context menu have no prompt.
+	if i.Message != nil {
+		if err := s.ChannelMessageDelete(i.ChannelID, i.Message.ID); err != nil {
+			log.Printf("Kunne ikkje slette rapportknapp: %v", err)
+		}
+	}
+}
+
+// processIncorrectWordReport legg til nye ord som ventande, startar ein
+// diskusjonstråd for kvart av dei og returnerer stadfestingsteksten. source er
+// reportSourceHammer eller reportSourceMenu.
+func (h *Handler) processIncorrectWordReport(words []string, source, reporterID, reporterName, guildID, originalChannelID, originalMessageID string) string {
+	log.Printf("[DEBUG] Handsamar feil-ord-rapport frå brukar %s: %s", reporterID, strings.Join(words, ", "))
+
+	var newWords []string
+	var existingWords []string
+
+	for _, word := range words {
+		// Check if word already exists
+		isBanned, existing, err := h.Bot.Database.IsBannedWord(word)
+		if err != nil {
+			log.Printf("Feil ved sjekking om ord '%s' finst: %v", word, err)
+			continue
+		}
+
+		// Rejected and unbanned words keep their row, so a new report
+		// puts the same row up for approval again
+		reopen := isBanned && (existing.ApprovalStatus == "rejected" || existing.UnbannedAt != nil)
+		if isBanned && !reopen {
+			existingWords = append(existingWords, word)
+			continue
+		}
+
+		newWords = append(newWords, word)
+		originalMessage := fmt.Sprintf("%s|%s", originalChannelID, originalMessageID)
+		var wordID int64
+		if reopen {
+			wordID = int64(existing.ID)
+			err = h.Bot.Database.ReopenBannedWord(existing.ID, reportReasons[source], reporterID, reporterName, originalMessage)
+		} else {
+			wordID, err = h.Bot.Database.AddBannedWordPending(word, reportReasons[source], reporterID, reporterName, "", originalMessage)
+		}
+		if err != nil {
+			log.Printf("Feil ved tillegging av ventande forbode ord '%s': %v", word, err)
+			continue
+		}
+
+		log.Printf("La til ventande forbode ord: %s med ID %d", word, wordID)
+		// Post to retting channel for approval
+		h.Services.Approval.PostPendingBannedWordToRettingChannel(wordID)
+		bannedWord, err := h.Bot.Database.GetBannedWordByID(int(wordID))
+		if err != nil {
+			continue
+		}
+		if bannedWord.ForumThreadID != nil && *bannedWord.ForumThreadID != "" {
+			// The old thread goes back to pending
+			h.Services.Approval.SyncForumThread(h.Bot.Session, bannedWord)
+		} else {
+			h.Services.Approval.StartForumThread(h.Bot.Session, bannedWord, guildID)
+		}
+	}
+
+	// Build confirmation with appropriate messaging
+	var confirmText string
+	if len(newWords) > 0 && len(existingWords) > 0 {
+		confirmText = fmt.Sprintf("Takk! Sende til godkjenning: %s. Finst allereie: %s", strings.Join(newWords, ", "), strings.Join(existingWords, ", "))
+	} else if len(newWords) > 0 {
+		confirmText = fmt.Sprintf("Takk! Desse orda er sende til godkjenning: %s", strings.Join(newWords, ", "))
+	} else {
+		confirmText = fmt.Sprintf("Alle orda finst allereie i lista over forbodne ord: %s", strings.Join(existingWords, ", "))
+	}
+
+	if len(newWords) > 0 {
+		confirmText += "\n\nDiskusjonen held fram i grammatikkforumet, der tråden viser om ordet er godkjent."
+	} else {
+		confirmText += "\n\nSjå eksisterande diskusjonar i grammatikkforumet for desse orda."
+	}
+	return confirmText
+}
+
+// parseReportTarget splits "<channel ID>:<message ID>" from a report custom ID
+func parseReportTarget(target string) (channelID, messageID string, ok bool) {
+	channelID, messageID, ok = strings.Cut(target, ":")
+	if !ok || channelID == "" || messageID == "" {
+		return "", "", false
+	}
+	return channelID, messageID, true
+}
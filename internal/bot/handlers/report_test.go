@@ -0,0 +1,258 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"askeladden/internal/bot/bottest"
+)
+
+// reportPrompt is the part of the posted prompt the tests look at
+type reportPrompt struct {
+	Components []struct {
+		Components []struct {
+			CustomID string `json:"custom_id"`
+		} `json:"components"`
+	} `json:"components"`
+	MessageReference *discordgo.MessageReference `json:"message_reference"`
+}
+
+func TestHammerReportFlow(t *testing.T) {
+	b, discord, db := bottest.New()
+	h := New(b)
+	discord.Respond("GET", "/channels/kanal/messages/orig", &discordgo.Message{ID: "orig", ChannelID: "kanal"})
+	discord.Respond("POST", "/channels/"+bottest.GrammarChannelID+"/threads", &discordgo.Channel{ID: "traad"})
+
+	h.ReactionAdd(b.Session, &discordgo.MessageReactionAdd{MessageReaction: &discordgo.MessageReaction{
+		UserID: "melder", ChannelID: "kanal", MessageID: "orig", GuildID: bottest.GuildID,
+		Emoji: discordgo.Emoji{Name: "🔨"},
+	}})
+
+	posts := discord.Requests("POST", "/channels/kanal/messages")
+	if len(posts) != 1 {
+		t.Fatalf("expected one prompt, got %d", len(posts))
+	}
+	var prompt reportPrompt
+	if err := posts[0].Decode(&prompt); err != nil {
+		t.Fatal(err)
+	}
+	if len(prompt.Components) != 1 || prompt.Components[0].Components[0].CustomID != "report_word:kanal:orig" {
+		t.Fatalf("prompt components = %+v", prompt.Components)
+	}
+	if prompt.MessageReference == nil || prompt.MessageReference.MessageID != "orig" {
+		t.Errorf("prompt should reply to the hammered message, got %+v", prompt.MessageReference)
+	}
+	if removed := discord.Requests("DELETE", "/channels/kanal/messages/orig/reactions/"); len(removed) != 1 || !strings.HasSuffix(removed[0].Path, "/melder") {
+		t.Errorf("hammer reaction removals = %+v", removed)
+	}
+
+	button := discordgo.MessageComponentInteractionData{CustomID: "report_word:kanal:orig"}
+	click := newInteraction("melder", button, discordgo.InteractionMessageComponent)
+	click.ChannelID = "kanal"
+	click.Message = &discordgo.Message{ID: "prompt", ChannelID: "kanal"}
+	h.InteractionCreate(b.Session, click)
+
+	modal := lastInteractionResponse(t, discord)
+	if modal.Type != discordgo.InteractionResponseModal || modal.Data.CustomID != "report_word_modal:hammer:kanal:orig" {
+		t.Fatalf("expected the report modal, got %+v", modal)
+	}
+
+	submit := discordgo.ModalSubmitInteractionData{
+		CustomID: modal.Data.CustomID,
+		Components: []discordgo.MessageComponent{
+			&discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+				&discordgo.TextInput{CustomID: "words", Value: "ikke, , hvordan "},
+			}},
+		},
+	}
+	interaction := newInteraction("melder", submit, discordgo.InteractionModalSubmit)
+	interaction.ChannelID = "kanal"
+	interaction.Member.User.Username = "Melder"
+	interaction.Message = &discordgo.Message{ID: "prompt", ChannelID: "kanal"}
+	h.InteractionCreate(b.Session, interaction)
+
+	for _, word := range []string{"ikke", "hvordan"} {
+		isBanned, bw, _ := db.IsBannedWord(word)
+		if !isBanned || bw.ApprovalStatus != "pending" || bw.AuthorName != "Melder" || *bw.OriginalMessageID != "kanal|orig" || bw.Reason != "Rapportert med 🔨" {
+			t.Errorf("%s = %+v", word, bw)
+		}
+		if bw.ForumThreadID == nil || *bw.ForumThreadID != "traad" {
+			t.Errorf("%s has no forum thread", word)
+		}
+	}
+	if starts := discord.Requests("POST", "/channels/"+bottest.GrammarChannelID+"/threads"); len(starts) != 2 {
+		t.Errorf("expected a forum thread per word, got %d", len(starts))
+	}
+	if embeds := discord.SentEmbeds(bottest.RettingChannelID); len(embeds) != 2 {
+		t.Errorf("expected both words in the retting channel, got %d", len(embeds))
+	}
+
+	confirm := lastInteractionResponse(t, discord)
+	if confirm.Type != discordgo.InteractionResponseChannelMessageWithSource || !strings.Contains(confirm.Data.Embeds[0].Description, "sende til godkjenning: ikke, hvordan") {
+		t.Errorf("confirmation = %+v", confirm)
+	}
+	if deletes := discord.Requests("DELETE", "/channels/kanal/messages/prompt"); len(deletes) != 1 {
+		t.Error("prompt was not removed after the report")
+	}
+	if posts := discord.Requests("POST", "/channels/kanal/messages"); len(posts) != 1 {
+		t.Errorf("report flow posted %d messages in the channel, want only the prompt", len(posts))
+	}
+}
+
+func TestUnusedReportPromptIsDeleted(t *testing.T) {
+	defer func(timeout time.Duration) { reportPromptTimeout = timeout }(reportPromptTimeout)
+	reportPromptTimeout = time.Millisecond
+	b, discord, _ := bottest.New()
+	discord.Respond("GET", "/channels/kanal/messages/orig", &discordgo.Message{ID: "orig", ChannelID: "kanal"})
+
+	New(b).ReactionAdd(b.Session, &discordgo.MessageReactionAdd{MessageReaction: &discordgo.MessageReaction{
+		UserID: "melder", ChannelID: "kanal", MessageID: "orig", GuildID: bottest.GuildID,
+		Emoji: discordgo.Emoji{Name: "🔨"},
+	}})
+
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(5 * time.Millisecond) {
+		if len(discord.Requests("DELETE", "/channels/kanal/messages/msg-1")) == 1 {
+			return
+		}
+	}
+	t.Error("unused prompt was not deleted after the timeout")
+}
+
+func TestReportModalRejectsBadTarget(t *testing.T) {
+	// A missing message ID, and a modal from before the source was added
+	for _, customID := range []string{"report_word_modal:hammer:kanal", "report_word_modal:kanal:orig"} {
+		b, discord, db := bottest.New()
+		h := New(b)
+
+		submit := discordgo.ModalSubmitInteractionData{
+			CustomID: customID,
+			Components: []discordgo.MessageComponent{
+				&discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+					&discordgo.TextInput{CustomID: "words", Value: "ikke"},
+				}},
+			},
+		}
+		h.InteractionCreate(b.Session, newInteraction("melder", submit, discordgo.InteractionModalSubmit))
+
+		if isBanned, _, _ := db.IsBannedWord("ikke"); isBanned {
+			t.Errorf("word added from the malformed custom ID %q", customID)
+		}
+		if response := lastInteractionResponse(t, discord); !strings.Contains(response.Data.Content, "Ugyldig") {
+			t.Errorf("response to %q = %+v", customID, response)
+		}
+	}
+}
+
+func TestReportFromContextMenu(t *testing.T) {
+	b, discord, db := bottest.New()
+	h := New(b)
+
+	command := discordgo.ApplicationCommandInteractionData{
+		Name:        reportWordCommandName,
+		CommandType: discordgo.MessageApplicationCommand,
+		TargetID:    "orig",
+	}
+	interaction := newInteraction("melder", command, discordgo.InteractionApplicationCommand)
+	interaction.ChannelID = "kanal"
+	interaction.Message = nil
+	h.InteractionCreate(b.Session, interaction)
+
+	modal := lastInteractionResponse(t, discord)
+	if modal.Type != discordgo.InteractionResponseModal || modal.Data.CustomID != "report_word_modal:meny:kanal:orig" {
+		t.Fatalf("expected the report modal, got %+v", modal)
+	}
+
+	submit := discordgo.ModalSubmitInteractionData{
+		CustomID: modal.Data.CustomID,
+		Components: []discordgo.MessageComponent{
+			&discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+				&discordgo.TextInput{CustomID: "words", Value: "ikke"},
+			}},
+		},
+	}
+	interaction = newInteraction("melder", submit, discordgo.InteractionModalSubmit)
+	interaction.ChannelID = "kanal"
+	interaction.Message = nil
+	h.InteractionCreate(b.Session, interaction)
+
+	if isBanned, bw, _ := db.IsBannedWord("ikke"); !isBanned || *bw.OriginalMessageID != "kanal|orig" || bw.Reason != "Rapportert frå kontekstmenyen" {
+		t.Errorf("reported word = %+v", bw)
+	}
+	if posts := discord.Requests("POST", "/channels/kanal/messages"); len(posts) != 0 {
+		t.Errorf("context menu report posted %d messages in the channel", len(posts))
+	}
+	if deletes := discord.Requests("DELETE", "/channels/kanal/messages"); len(deletes) != 0 {
+		t.Errorf("context menu report deleted a message")
+	}
+}
+
+func TestReportReopensRejectedAndUnbannedWords(t *testing.T) {
+	b, discord, db := bottest.New()
+	h := New(b)
+	rejected, _ := db.AddBannedWordPending("ikke", "", "gamal", "Gamal", "traad", "c|m")
+	db.RejectBannedWord(int(rejected), "rett")
+	db.UpdateBannedWordRejectionReason(int(rejected), "rett ord")
+	addApprovedWord(db, "hvordan")
+	proposal, _ := db.AddUnbanProposal(2, "forslag", "Forslag", "er lov")
+	db.ApproveUnbanProposal(int(proposal), []string{"opp"}, []string{"rett"})
+	db.AddBannedWordPending("noko", "", "gamal", "Gamal", "", "c|m")
+	discord.Respond("GET", "/channels/traad", &discordgo.Channel{
+		ID:             "traad",
+		AppliedTags:    []string{"tag-avvist"},
+		ThreadMetadata: &discordgo.ThreadMetadata{Archived: true, Locked: true},
+	})
+	discord.Respond("POST", "/channels/"+bottest.GrammarChannelID+"/threads", &discordgo.Channel{ID: "ny-traad"})
+
+	text := h.processIncorrectWordReport([]string{"ikke", "hvordan", "noko"}, reportSourceHammer, "melder", "Melder", bottest.GuildID, "kanal", "orig")
+
+	bw, _ := db.GetBannedWordByID(int(rejected))
+	if bw.ApprovalStatus != "pending" || bw.AuthorID != "melder" || bw.RejectedBy != nil || bw.RejectionReason != nil || bw.ApprovalMessageID == nil {
+		t.Errorf("reopened rejected word = %+v", bw)
+	}
+	edits := discord.ChannelEdits("traad")
+	if len(edits) != 1 || strings.Join(*edits[0].AppliedTags, ",") != "tag-ventar" || edits[0].Locked == nil || *edits[0].Locked || *edits[0].Archived {
+		t.Errorf("thread edits = %+v, want an open pending thread", edits)
+	}
+
+	bw, _ = db.GetBannedWordByID(2)
+	if bw.ApprovalStatus != "pending" || bw.UnbannedAt != nil || bw.OpplysarApprovedBy != nil || bw.ForumThreadID == nil || *bw.ForumThreadID != "ny-traad" {
+		t.Errorf("reopened unbanned word = %+v", bw)
+	}
+	if found := b.BannedWords.FindAll("hvordan"); len(found) != 0 {
+		t.Error("reopened word is matched before it is approved again")
+	}
+
+	if embeds := discord.SentEmbeds(bottest.RettingChannelID); len(embeds) != 2 {
+		t.Errorf("posted %d reports for approval, want 2", len(embeds))
+	}
+	if !strings.Contains(text, "Sende til godkjenning: ikke, hvordan") || !strings.Contains(text, "Finst allereie: noko") {
+		t.Errorf("confirmation = %q", text)
+	}
+}
+
+func TestReadyRegistersContextMenu(t *testing.T) {
+	b, discord, _ := bottest.New()
+	h := New(b)
+
+	h.Ready(b.Session, &discordgo.Ready{Guilds: []*discordgo.Guild{{ID: bottest.GuildID}}})
+
+	requests := discord.Requests("PUT", "/applications/"+bottest.BotUserID+"/guilds/"+bottest.GuildID+"/commands")
+	if len(requests) != 1 {
+		t.Fatalf("expected one bulk overwrite, got %d", len(requests))
+	}
+	var registered []*discordgo.ApplicationCommand
+	if err := requests[0].Decode(&registered); err != nil {
+		t.Fatal(err)
+	}
+	if len(registered) != 2 || registered[0].Name != reportWordCommandName || registered[1].Name != rewriteCommandName {
+		t.Fatalf("registered commands = %+v", registered)
+	}
+	for _, command := range registered {
+		if command.Type != discordgo.MessageApplicationCommand {
+			t.Errorf("%s is not a message command", command.Name)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"log"
+
+	"github.com/bwmarrin/discordgo"
+
+	"askeladden/internal/commands"
+)
+
+// rewriteCommandName is the message context-menu command for rewriting a message to nynorsk
+const rewriteCommandName = "Omset til nynorsk"
+
+// handleRewriteCommand svarar med meldinga skriven om til nynorsk, berre synleg for brukaren
+func (h *Handler) handleRewriteCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	data := i.ApplicationCommandData()
+	var message *discordgo.Message
+	if data.Resolved != nil {
+		message = data.Resolved.Messages[data.TargetID]
+	}
+	if message == nil || message.Content == "" {
+		respondEphemeral(s, i, "Fann ingen tekst å omsetje i denne meldinga.")
+		return
+	}
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{commands.RewriteEmbed(s, h.Bot, message.Content)},
+			Flags:  discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		log.Printf("Kunne ikkje sende omsetjinga: %v", err)
+	}
+}
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+
+	"askeladden/internal/bot/bottest"
+)
+
+func TestRewriteFromContextMenu(t *testing.T) {
+	b, discord, db := bottest.New()
+	h := New(b)
+	addApprovedWord(db, "ikke")
+	db.UpdateBannedWordSuggestions(1, []string{"ikkje"})
+
+	command := discordgo.ApplicationCommandInteractionData{
+		Name:        rewriteCommandName,
+		CommandType: discordgo.MessageApplicationCommand,
+		TargetID:    "orig",
+		Resolved: &discordgo.ApplicationCommandInteractionDataResolved{
+			Messages: map[string]*discordgo.Message{"orig": {ID: "orig", Content: "Eg veit ikke"}},
+		},
+	}
+	interaction := newInteraction("lesar", command, discordgo.InteractionApplicationCommand)
+	interaction.ChannelID = "kanal"
+	interaction.Message = nil
+	h.InteractionCreate(b.Session, interaction)
+
+	response := lastInteractionResponse(t, discord)
+	if len(response.Data.Embeds) != 1 || response.Data.Embeds[0].Description != "Eg veit ikkje" {
+		t.Fatalf("response = %+v", response)
+	}
+	if posts := discord.Requests("POST", "/channels/kanal/messages"); len(posts) != 0 {
+		t.Error("rewrite was posted in the channel instead of only to the user")
+	}
+}
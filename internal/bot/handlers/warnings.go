@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/bwmarrin/discordgo"
+
+	"askeladden/internal/bot/services"
+	"askeladden/internal/database"
+)
+
+// checkForBannedWords sjekkar om ei melding inneheld forbodne ord og viser åtvaringar
+func (h *Handler) checkForBannedWords(s *discordgo.Session, m *discordgo.MessageCreate) {
+	// Look the words up in the cached matcher instead of asking the database per word
+	foundBannedWords := h.Bot.BannedWords.FindAll(m.Content)
+	for _, bannedWord := range foundBannedWords {
+		log.Printf("Oppdaga forbode ord '%s' i melding frå brukar %s", bannedWord.Word, m.Author.ID)
+		h.Bot.Database.AddBannedWordHit(bannedWord.ID, m.Author.ID, m.ChannelID, m.ID)
+	}
+
+	if len(foundBannedWords) > 0 {
+		h.sendBannedWordWarning(s, m, foundBannedWords)
+	}
+}
+
+// repeatedWordReaction is added instead of a new warning when a user repeats
+// a word within the cooldown, or the channel had a warning very recently
+const repeatedWordReaction = "⚠️"
+
+// sendBannedWordWarning sender åtvaring om oppdaga forbodne ord, med mindre kanalen
+// er unnateken eller brukaren har valt åtvaringar på DM eller ingen åtvaringar.
+// Ord brukaren nyleg er åtvara om, vert berre talde og markerte med ein reaksjon.
+func (h *Handler) sendBannedWordWarning(s *discordgo.Session, m *discordgo.MessageCreate, bannedWords []*database.BannedWord) {
+	if !h.warningsAllowedIn(s, m.ChannelID) {
+		return
+	}
+
+	mode, err := h.Bot.Database.GetUserWarningMode(m.Author.ID)
+	if err != nil {
+		log.Printf("Kunne ikkje hente åtvaringsval for brukar %s: %v", m.Author.ID, err)
+	}
+	if mode == database.WarningModeOff {
+		return
+	}
+
+	rules := h.Bot.Config.BannedWords.Warnings
+
+	// Count repeats on the earlier warning instead of warning again
+	var freshWords []*database.BannedWord
+	for _, bannedWord := range bannedWords {
+		recent, err := h.Bot.Database.GetRecentBannedWordWarning(m.Author.ID, bannedWord.ID, rules.UserCooldown())
+		if err != nil {
+			log.Printf("Kunne ikkje sjekke nedkjøling for ord '%s': %v", bannedWord.Word, err)
+		}
+		if recent != nil {
+			h.Bot.Database.RecordBannedWordWarningHit(recent.ID)
+			continue
+		}
+		freshWords = append(freshWords, bannedWord)
+	}
+
+	// Hints are gentle, so repeats of low severity words aren't marked
+	gentle := database.HighestSeverity(bannedWords) == database.SeverityLow
+
+	if len(freshWords) == 0 {
+		if mode == database.WarningModePublic && !gentle {
+			s.MessageReactionAdd(m.ChannelID, m.ID, repeatedWordReaction)
+		}
+		return
+	}
+
+	warningEmbed := services.CreateBannedWordWarningEmbed(freshWords)
+
+	if mode == database.WarningModeDM {
+		if warning := h.sendBannedWordWarningDM(s, m, warningEmbed); warning != nil {
+			h.recordWarnings(m, freshWords, warning)
+		}
+		return
+	}
+
+	// One warning per channel cooldown, so a busy conversation isn't flooded
+	recentInChannel, err := h.Bot.Database.HasRecentWarningInChannel(m.ChannelID, rules.ChannelCooldown())
+	if err != nil {
+		log.Printf("Kunne ikkje sjekke nedkjøling for kanal %s: %v", m.ChannelID, err)
+	}
+	if recentInChannel {
+		if !gentle {
+			s.MessageReactionAdd(m.ChannelID, m.ID, repeatedWordReaction)
+		}
+		return
+	}
+
+	// Send as a reply to the original message. A hint doesn't ping the author.
+	reply := &discordgo.MessageSend{
+		Embed: warningEmbed,
+		Reference: &discordgo.MessageReference{
+			MessageID: m.ID,
+			ChannelID: m.ChannelID,
+			GuildID:   m.GuildID,
+		},
+	}
+	if database.HighestSeverity(freshWords) == database.SeverityLow {
+		reply.AllowedMentions = &discordgo.MessageAllowedMentions{RepliedUser: false}
+	}
+
+	warning, err := s.ChannelMessageSendComplex(m.ChannelID, reply)
+	if err != nil {
+		log.Printf("Kunne ikkje sende åtvaring om forbodne ord: %v", err)
+		return
+	}
+	h.recordWarnings(m, freshWords, warning)
+}
+
+// sendBannedWordWarningDM sender åtvaringa som privatmelding med lenkje til meldinga
+func (h *Handler) sendBannedWordWarningDM(s *discordgo.Session, m *discordgo.MessageCreate, warningEmbed *discordgo.MessageEmbed) *discordgo.Message {
+	privateChannel, err := s.UserChannelCreate(m.Author.ID)
+	if err != nil {
+		log.Printf("Kunne ikkje opne privatkanal for åtvaring: %v", err)
+		return nil
+	}
+
+	warningEmbed.Fields = append(warningEmbed.Fields, messageLinkField(m.GuildID, m.ChannelID, m.ID))
+
+	warning, err := s.ChannelMessageSendEmbed(privateChannel.ID, warningEmbed)
+	if err != nil {
+		log.Printf("Kunne ikkje sende åtvaring som privatmelding: %v", err)
+		return nil
+	}
+	return warning
+}
+
+// messageLinkField lenkjer til meldinga ei åtvaring på DM gjeld
+func messageLinkField(guildID, channelID, messageID string) *discordgo.MessageEmbedField {
+	return &discordgo.MessageEmbedField{
+		Name:  "📍 Melding",
+		Value: fmt.Sprintf("[Hopp til meldinga](https://discord.com/channels/%s/%s/%s)", guildID, channelID, messageID),
+	}
+}
+
+// recordWarnings lagrar ei åtvaring per ord, slik at nedkjølinga overlever omstart
+func (h *Handler) recordWarnings(m *discordgo.MessageCreate, bannedWords []*database.BannedWord, warning *discordgo.Message) {
+	for _, bannedWord := range bannedWords {
+		_, err := h.Bot.Database.AddBannedWordWarning(m.Author.ID, bannedWord.ID, m.ChannelID, m.ID, warning.ChannelID, warning.ID)
+		if err != nil {
+			log.Printf("Kunne ikkje lagre åtvaring for ord '%s': %v", bannedWord.Word, err)
+		}
+	}
+}
+
+// warningsAllowedIn sjekkar kanal- og kategorireglane for åtvaringar. Tråder
+// følgjer reglane for kanalen dei er i.
+func (h *Handler) warningsAllowedIn(s *discordgo.Session, channelID string) bool {
+	rules := h.Bot.Config.BannedWords.Warnings
+	channelIDs := []string{channelID}
+	categoryID := ""
+
+	if channel := lookupChannel(s, channelID); channel != nil {
+		categoryID = channel.ParentID
+		if channel.IsThread() {
+			channelIDs = append(channelIDs, channel.ParentID)
+			categoryID = ""
+			if parent := lookupChannel(s, channel.ParentID); parent != nil {
+				categoryID = parent.ParentID
+			}
+		}
+	}
+	return rules.Allows(channelIDs, categoryID)
+}
+
+// lookupChannel hentar ein kanal frå state, eller frå Discord om han ikkje er der
+func lookupChannel(s *discordgo.Session, channelID string) *discordgo.Channel {
+	if channel, err := s.State.Channel(channelID); err == nil {
+		return channel
+	}
+	channel, err := s.Channel(channelID)
+	if err != nil {
+		log.Printf("Kunne ikkje hente kanal %s: %v", channelID, err)
+		return nil
+	}
+	return channel
+}
@@ -0,0 +1,255 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"askeladden/internal/bot/bottest"
+	"askeladden/internal/database"
+	"askeladden/internal/database/databasetest"
+)
+
+// newUserMessage is a message from a regular user in a channel
+func newUserMessage(content, channelID string) *discordgo.MessageCreate {
+	return &discordgo.MessageCreate{Message: &discordgo.Message{
+		ID:        "src",
+		Content:   content,
+		ChannelID: channelID,
+		GuildID:   bottest.GuildID,
+		Author:    &discordgo.User{ID: "skribent", Username: "skribent"},
+	}}
+}
+
+// addApprovedWord adds a fully approved banned word to the fake database
+func addApprovedWord(db *databasetest.DB, word string) {
+	id, _ := db.AddBannedWordPending(word, "", "u1", "brukar", "", "")
+	db.ApproveBannedWordCombined(int(id), []string{"opp"}, []string{"rett"})
+}
+
+// addChannel puts a channel in the session state
+func addChannel(s *discordgo.Session, channel *discordgo.Channel) {
+	channel.GuildID = bottest.GuildID
+	s.State.ChannelAdd(channel)
+}
+
+func TestWarningChannelRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   func(h *Handler)
+		channel string
+		warned  bool
+	}{
+		{"no rules", func(*Handler) {}, "prat", true},
+		{"denied channel", func(h *Handler) {
+			h.Bot.Config.BannedWords.Warnings.DenyChannels = []string{"english"}
+		}, "english", false},
+		{"denied category", func(h *Handler) {
+			h.Bot.Config.BannedWords.Warnings.DenyCategories = []string{"stab"}
+		}, "stabsrom", false},
+		{"channel allow beats category deny", func(h *Handler) {
+			h.Bot.Config.BannedWords.Warnings.DenyCategories = []string{"stab"}
+			h.Bot.Config.BannedWords.Warnings.AllowChannels = []string{"stabsrom"}
+		}, "stabsrom", true},
+		{"not in allow list", func(h *Handler) {
+			h.Bot.Config.BannedWords.Warnings.AllowCategories = []string{"nynorsk"}
+		}, "english", false},
+		{"thread follows parent", func(h *Handler) {
+			h.Bot.Config.BannedWords.Warnings.DenyChannels = []string{"english"}
+		}, "english-traad", false},
+		{"thread follows parent category", func(h *Handler) {
+			h.Bot.Config.BannedWords.Warnings.AllowCategories = []string{"nynorsk"}
+		}, "prat-traad", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, discord, db := bottest.New()
+			h := New(b)
+			addApprovedWord(db, "ikke")
+			addChannel(b.Session, &discordgo.Channel{ID: "nynorsk", Type: discordgo.ChannelTypeGuildCategory})
+			addChannel(b.Session, &discordgo.Channel{ID: "stab", Type: discordgo.ChannelTypeGuildCategory})
+			addChannel(b.Session, &discordgo.Channel{ID: "prat", ParentID: "nynorsk"})
+			addChannel(b.Session, &discordgo.Channel{ID: "english"})
+			addChannel(b.Session, &discordgo.Channel{ID: "stabsrom", ParentID: "stab"})
+			addChannel(b.Session, &discordgo.Channel{ID: "english-traad", ParentID: "english", Type: discordgo.ChannelTypeGuildPublicThread})
+			addChannel(b.Session, &discordgo.Channel{ID: "prat-traad", ParentID: "prat", Type: discordgo.ChannelTypeGuildPublicThread})
+			tt.rules(h)
+
+			h.MessageCreate(b.Session, newUserMessage("Det er ikke sant", tt.channel))
+
+			if warned := len(discord.Requests("POST", "/channels/"+tt.channel+"/messages")) > 0; warned != tt.warned {
+				t.Errorf("warned = %v, want %v", warned, tt.warned)
+			}
+		})
+	}
+}
+
+func TestWarningUserModes(t *testing.T) {
+	b, discord, db := bottest.New()
+	h := New(b)
+	addApprovedWord(db, "ikke")
+
+	db.SetUserWarningMode("skribent", database.WarningModeOff)
+	h.MessageCreate(b.Session, newUserMessage("ikke", bottest.DefaultChannelID))
+	if posts := discord.Requests("POST", "/channels/"); len(posts) != 0 {
+		t.Fatalf("warning sent with warnings off: %d requests", len(posts))
+	}
+
+	db.SetUserWarningMode("skribent", database.WarningModeDM)
+	h.MessageCreate(b.Session, newUserMessage("ikke", bottest.DefaultChannelID))
+	if posts := discord.Requests("POST", "/channels/"+bottest.DefaultChannelID+"/messages"); len(posts) != 0 {
+		t.Error("public warning sent with DM mode")
+	}
+	embeds := discord.SentEmbeds("dm-1")
+	if len(embeds) != 1 || len(embeds[0].Fields) == 0 || !strings.Contains(embeds[0].Fields[len(embeds[0].Fields)-1].Value, "/src)") {
+		t.Errorf("DM warning = %+v", embeds)
+	}
+}
+
+// messageFrom is a message from a given user
+func messageFrom(userID, messageID, content string) *discordgo.MessageCreate {
+	m := newUserMessage(content, bottest.DefaultChannelID)
+	m.ID = messageID
+	m.Author = &discordgo.User{ID: userID, Username: userID}
+	return m
+}
+
+// warningsPosted counts warnings posted in the default channel
+func warningsPosted(discord *bottest.Discord) int {
+	return len(discord.Requests("POST", "/channels/"+bottest.DefaultChannelID+"/messages"))
+}
+
+// reacted reports whether the bot reacted to a message in the default channel
+func reacted(discord *bottest.Discord, messageID string) bool {
+	return len(discord.Requests("PUT", "/channels/"+bottest.DefaultChannelID+"/messages/"+messageID+"/reactions/")) > 0
+}
+
+func TestWarningUserCooldown(t *testing.T) {
+	b, discord, db := bottest.New()
+	h := New(b)
+	addApprovedWord(db, "ikke")
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	db.Now = func() time.Time { return now }
+
+	h.MessageCreate(b.Session, messageFrom("skribent", "m1", "ikke"))
+	h.MessageCreate(b.Session, messageFrom("skribent", "m2", "Nei, ikke det"))
+
+	if n := warningsPosted(discord); n != 1 {
+		t.Fatalf("posted %d warnings, want 1", n)
+	}
+	if !reacted(discord, "m2") {
+		t.Error("repeat was not marked with a reaction")
+	}
+	if w, _ := db.GetRecentBannedWordWarning("skribent", 1, time.Minute); w == nil || w.HitCount != 2 || w.SourceMessageID != "m1" {
+		t.Errorf("warning = %+v, want two hits on the first warning", w)
+	}
+
+	// The cooldown is kept in the database, so a restart doesn't reset it
+	h = New(b)
+	now = now.Add(29 * time.Minute)
+	h.MessageCreate(b.Session, messageFrom("skribent", "m3", "ikke"))
+	if n := warningsPosted(discord); n != 1 {
+		t.Fatalf("warned again within the cooldown after a restart")
+	}
+
+	// The last hit extends the window
+	now = now.Add(29 * time.Minute)
+	h.MessageCreate(b.Session, messageFrom("skribent", "m4", "ikke"))
+	if n := warningsPosted(discord); n != 1 {
+		t.Fatalf("warned again within the cooldown of the last hit")
+	}
+
+	now = now.Add(31 * time.Minute)
+	h.MessageCreate(b.Session, messageFrom("skribent", "m5", "ikke"))
+	if n := warningsPosted(discord); n != 2 {
+		t.Errorf("posted %d warnings after the cooldown, want 2", n)
+	}
+}
+
+func TestWarningChannelCooldown(t *testing.T) {
+	b, discord, db := bottest.New()
+	h := New(b)
+	addApprovedWord(db, "ikke")
+	addApprovedWord(db, "hvordan")
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	db.Now = func() time.Time { return now }
+
+	h.MessageCreate(b.Session, messageFrom("a", "m1", "ikke"))
+	now = now.Add(30 * time.Second)
+	h.MessageCreate(b.Session, messageFrom("b", "m2", "hvordan"))
+
+	if n := warningsPosted(discord); n != 1 {
+		t.Fatalf("posted %d warnings within the channel cooldown, want 1", n)
+	}
+	if !reacted(discord, "m2") {
+		t.Error("second user's message was not marked with a reaction")
+	}
+
+	// Not recorded during the channel cooldown, so b gets a proper warning later
+	now = now.Add(time.Minute)
+	h.MessageCreate(b.Session, messageFrom("b", "m3", "hvordan"))
+	if n := warningsPosted(discord); n != 2 {
+		t.Errorf("posted %d warnings after the channel cooldown, want 2", n)
+	}
+}
+
+func TestWarningCooldownFromConfig(t *testing.T) {
+	b, discord, db := bottest.New()
+	h := New(b)
+	b.Config.BannedWords.Warnings.UserCooldownMinutes = 5
+	addApprovedWord(db, "ikke")
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	db.Now = func() time.Time { return now }
+
+	h.MessageCreate(b.Session, messageFrom("skribent", "m1", "ikke"))
+	now = now.Add(6 * time.Minute)
+	h.MessageCreate(b.Session, messageFrom("skribent", "m2", "ikke"))
+
+	if n := warningsPosted(discord); n != 2 {
+		t.Errorf("posted %d warnings with a 5 minute cooldown, want 2", n)
+	}
+}
+
+func TestBannedWordHitsAreLogged(t *testing.T) {
+	b, _, db := bottest.New()
+	h := New(b)
+	addApprovedWord(db, "ikke")
+	db.SetUserWarningMode("skribent", database.WarningModeOff)
+
+	// Logged even when no warning is sent
+	h.MessageCreate(b.Session, messageFrom("skribent", "m1", "ikke"))
+	h.MessageCreate(b.Session, messageFrom("skribent", "m2", "ikke"))
+
+	top, _ := db.GetTopBannedWords("skribent", time.Now().Add(-time.Hour), 5)
+	if len(top) != 1 || top[0].Word != "ikke" || top[0].Count != 2 {
+		t.Errorf("top words = %+v, want ikke twice", top)
+	}
+}
+
+func TestLowSeverityWarningIsGentle(t *testing.T) {
+	b, discord, db := bottest.New()
+	h := New(b)
+	addApprovedWord(db, "nogen")
+	db.UpdateBannedWordCategory(1, database.CategorySidemal, database.SeverityLow)
+
+	h.MessageCreate(b.Session, messageFrom("skribent", "m1", "nogen"))
+	h.MessageCreate(b.Session, messageFrom("skribent", "m2", "nogen"))
+
+	posts := discord.Requests("POST", "/channels/"+bottest.DefaultChannelID+"/messages")
+	if len(posts) != 1 {
+		t.Fatalf("posted %d warnings, want 1", len(posts))
+	}
+	var reply struct {
+		AllowedMentions *discordgo.MessageAllowedMentions `json:"allowed_mentions"`
+	}
+	posts[0].Decode(&reply)
+	if reply.AllowedMentions == nil || reply.AllowedMentions.RepliedUser {
+		t.Errorf("hint pings the author: %+v", reply.AllowedMentions)
+	}
+	if reacted(discord, "m2") {
+		t.Error("repeat of a hint was marked with a reaction")
+	}
+}
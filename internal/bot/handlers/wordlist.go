@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"log"
+
+	"github.com/bwmarrin/discordgo"
+
+	"askeladden/internal/commands"
+)
+
+// handleWordListButton blar til ei anna side i ordlista
+func (h *Handler) handleWordListButton(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	prefix, page, ok := commands.ParseWordListButton(i.MessageComponentData().CustomID)
+	if !ok {
+		respondEphemeral(s, i, "Ugyldig knapp.")
+		return
+	}
+
+	embed, components, err := commands.WordListPage(s, h.Bot, prefix, page)
+	if err != nil {
+		respondEphemeral(s, i, "Kunne ikkje hente ordlista.")
+		return
+	}
+
+	// Always send the components, so the buttons go away if the list shrank to one page
+	if components == nil {
+		components = []discordgo.MessageComponent{}
+	}
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{embed},
+			Components: components,
+		},
+	})
+	if err != nil {
+		log.Printf("Kunne ikkje bla i ordlista: %v", err)
+	}
+}
@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+
+	"askeladden/internal/bot/bottest"
+)
+
+func TestWordListButtonTurnsPage(t *testing.T) {
+	b, discord, db := bottest.New()
+	h := New(b)
+	for _, word := range []string{"bare", "hva", "hvis", "hvordan", "ikke", "mye"} {
+		addApprovedWord(db, word)
+	}
+
+	button := discordgo.MessageComponentInteractionData{CustomID: "word_list:1:"}
+	h.InteractionCreate(b.Session, newInteraction("lesar", button, discordgo.InteractionMessageComponent))
+
+	response := lastInteractionResponse(t, discord)
+	if response.Type != discordgo.InteractionResponseUpdateMessage {
+		t.Fatalf("response type = %v, want an update of the list", response.Type)
+	}
+	if len(response.Data.Embeds) != 1 || len(response.Data.Embeds[0].Fields) != 1 || response.Data.Embeds[0].Fields[0].Name != "mye" {
+		t.Errorf("page = %+v", response.Data.Embeds)
+	}
+	if len(response.Data.Components) != 1 {
+		t.Errorf("components = %d rows, want the page buttons", len(response.Data.Components))
+	}
+}
@@ -136,6 +136,10 @@ func (s *ApprovalService) PostPendingBannedWordToRettingChannel(bannedWordID int
 	hammerUser, err := s.Bot.Session.User(bannedWord.AuthorID)
 
 	approvalEmbed := CreateApprovalEmbed(bannedWord.Word, "⏳ Opplysar-godkjenning: ventar\n⏳ Rettskrivar-godkjenning: ventar", hammerUser)
+	approvalEmbed.Fields = append(approvalEmbed.Fields, BannedWordCategoryField(bannedWord))
+	if field := DictionaryField(s.Bot.Dictionary, bannedWord.Word); field != nil {
+		approvalEmbed.Fields = append(approvalEmbed.Fields, field)
+	}
 
 	message, err := s.Bot.Session.ChannelMessageSendEmbed(channelID, approvalEmbed)
 	if err != nil {
@@ -143,8 +147,9 @@ func (s *ApprovalService) PostPendingBannedWordToRettingChannel(bannedWordID int
 		return
 	}
 
-	// Add reaction emoji
+	// Add reaction emojis
 	s.Bot.Session.MessageReactionAdd(channelID, message.ID, "👍")
+	s.Bot.Session.MessageReactionAdd(channelID, message.ID, "👎")
 
 	// Update the database with the approval message ID
 	err = s.Bot.Database.UpdateBannedWordApprovalMessageID(int(bannedWord.ID), message.ID)
@@ -168,7 +173,6 @@ func (s *ApprovalService) PostBannedWordReport(session *discordgo.Session, words
 		reporterName = reporter.Username
 	}
 
-	// For newly approved banned words, always create a forum thread
 	// Check if any words already have forum threads (for logging purposes)
 	var existingThreads []string
 
@@ -186,7 +190,7 @@ func (s *ApprovalService) PostBannedWordReport(session *discordgo.Session, words
 		}
 	}
 
-	// Always create forum threads for newly approved words
+	// Always create forum threads for newly reported words
 	newWords := words
 
 	// Create forum post for new words - use just the word as title
@@ -197,9 +201,14 @@ func (s *ApprovalService) PostBannedWordReport(session *discordgo.Session, words
 
 	// Note: We no longer need guild ID since we simplified the forum message
 
-	// Create forum post (thread in forum channel) with minimal initial message
+	// Create forum post (thread in forum channel) with minimal initial message.
+	// Threads are created when words are reported, so they start out pending.
 	initialMessage := "🔨 Grammatikkdiskusjon"
-	thread, err := session.ForumThreadStart(s.Bot.Config.Grammar.ChannelID, postTitle, 60, initialMessage)
+	thread, err := session.ForumThreadStartComplex(s.Bot.Config.Grammar.ChannelID, &discordgo.ThreadStart{
+		Name:                postTitle,
+		AutoArchiveDuration: 60,
+		AppliedTags:         s.forumTagIDs(session, []string{s.Bot.Config.Grammar.Tags.PendingTag()}),
+	}, &discordgo.MessageSend{Content: initialMessage})
 	if err != nil {
 		log.Printf("Failed to create forum post (may require approval): %v", err)
 		// Forum post creation failed - likely requires manual approval
@@ -272,3 +281,114 @@ func (s *ApprovalService) NotifyUserRejection(session *discordgo.Session, questi
 		log.Printf("Failed to send rejection notification to user: %v", err)
 	}
 }
+
+// NotifyBannedWordRejection tells the reporter that their banned word report was
+// rejected, including the reason if the rejector has given one.
+func (s *ApprovalService) NotifyBannedWordRejection(session *discordgo.Session, bannedWord *database.BannedWord) {
+	privateChannel, err := session.UserChannelCreate(bannedWord.AuthorID)
+	if err != nil {
+		log.Printf("Failed to create private channel for banned word rejection notification: %v", err)
+		return
+	}
+
+	rejectorName := "ein opplysar eller rettskrivar"
+	if bannedWord.RejectedBy != nil {
+		if rejector, err := session.User(*bannedWord.RejectedBy); err == nil {
+			rejectorName = rejector.Username
+		}
+	}
+
+	description := fmt.Sprintf("Rapporten din om ordet **\"%s\"** har blitt avvist av %s.", bannedWord.Word, rejectorName)
+	if bannedWord.RejectionReason != nil && *bannedWord.RejectionReason != "" {
+		description += fmt.Sprintf("\n\n**Grunngiving:** %s", *bannedWord.RejectionReason)
+	}
+	description += "\n\nTakk for at du melde det likevel!"
+
+	embed := CreateBotEmbed(session, "❌ Ordrapport avvist", description, EmbedTypeError)
+	_, err = session.ChannelMessageSendEmbed(privateChannel.ID, embed)
+	if err != nil {
+		log.Printf("Failed to send banned word rejection notification to user: %v", err)
+	}
+}
+
+// NotifyBannedWordRejectionReason sends the reporter the reason for a rejection
+// given after they were told about it by NotifyBannedWordRejection
+func (s *ApprovalService) NotifyBannedWordRejectionReason(session *discordgo.Session, bannedWord *database.BannedWord) {
+	if bannedWord.RejectionReason == nil || *bannedWord.RejectionReason == "" {
+		return
+	}
+	privateChannel, err := session.UserChannelCreate(bannedWord.AuthorID)
+	if err != nil {
+		log.Printf("Failed to create private channel for banned word rejection reason: %v", err)
+		return
+	}
+
+	description := fmt.Sprintf("Grunngiving for at rapporten om **\"%s\"** vart avvist:\n\n%s", bannedWord.Word, *bannedWord.RejectionReason)
+	embed := CreateBotEmbed(session, "📝 Grunngiving", description, EmbedTypeInfo)
+	if _, err := session.ChannelMessageSendEmbed(privateChannel.ID, embed); err != nil {
+		log.Printf("Failed to send banned word rejection reason to user: %v", err)
+	}
+}
+
+// PostUnbanProposalToRettingChannel posts a new unban proposal to the retting channel for approval.
+func (s *ApprovalService) PostUnbanProposalToRettingChannel(proposalID int64) {
+	proposal, err := s.Bot.Database.GetUnbanProposalByID(int(proposalID))
+	if err != nil {
+		log.Printf("Failed to get unban proposal for retting channel posting: %v", err)
+		return
+	}
+
+	bannedWord, err := s.Bot.Database.GetBannedWordByID(proposal.WordID)
+	if err != nil {
+		log.Printf("Failed to get banned word for unban proposal %d: %v", proposal.ID, err)
+		return
+	}
+
+	channelID := s.Bot.Config.BannedWords.ApprovalChannelID
+	if channelID == "" {
+		log.Println("Retting channel is not configured")
+		return
+	}
+
+	proposer, _ := s.Bot.Session.User(proposal.ProposerID)
+	embed := CreateUnbanProposalEmbed(bannedWord, proposal, "⏳ Opplysar-godkjenning: ventar\n⏳ Rettskrivar-godkjenning: ventar", ColorError, proposer)
+
+	message, err := s.Bot.Session.ChannelMessageSendEmbed(channelID, embed)
+	if err != nil {
+		log.Printf("Failed to post unban proposal to retting channel: %v", err)
+		return
+	}
+
+	s.Bot.Session.MessageReactionAdd(channelID, message.ID, "👍")
+	s.Bot.Session.MessageReactionAdd(channelID, message.ID, "👎")
+
+	if err := s.Bot.Database.UpdateUnbanProposalApprovalMessageID(proposal.ID, message.ID); err != nil {
+		log.Printf("Failed to update approval message ID for unban proposal: %v", err)
+	}
+}
+
+// PostUnbanOutcome posts the outcome of an unban proposal in the word's forum
+// thread, if it has one, so the discussion keeps the whole history.
+func (s *ApprovalService) PostUnbanOutcome(session *discordgo.Session, bannedWord *database.BannedWord, proposal *database.UnbanProposal, deciderID string) {
+	if bannedWord.ForumThreadID == nil || *bannedWord.ForumThreadID == "" {
+		return
+	}
+
+	var embed *discordgo.MessageEmbed
+	if proposal.Status == "approved" {
+		embed = CreateBotEmbed(session, "🔓 Forbodet er oppheva",
+			fmt.Sprintf("Opplysarar og rettskrivarar har godkjent framlegget frå <@%s> om å oppheve forbodet mot **%s**.", proposal.ProposerID, bannedWord.Word),
+			EmbedTypeSuccess)
+	} else {
+		embed = CreateBotEmbed(session, "🔒 Forbodet står ved lag",
+			fmt.Sprintf("Framlegget frå <@%s> om å oppheve forbodet mot **%s** vart avvist av <@%s>.", proposal.ProposerID, bannedWord.Word, deciderID),
+			EmbedTypeError)
+	}
+	if proposal.Reason != "" {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{Name: "📝 Grunngiving for framlegget", Value: proposal.Reason})
+	}
+
+	if _, err := session.ChannelMessageSendEmbed(*bannedWord.ForumThreadID, embed); err != nil {
+		log.Printf("Failed to post unban outcome to forum thread %s: %v", *bannedWord.ForumThreadID, err)
+	}
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"askeladden/internal/bot/bottest"
+	"askeladden/internal/dictionary"
+)
+
+func TestRettingPostShowsDictionaryStatus(t *testing.T) {
+	b, discord, db := bottest.New()
+	b.Dictionary = dictionary.New([]string{"ikkje", "sjølv"}, []string{"ikke", "sjølv"})
+	service := &ApprovalService{Bot: b}
+
+	for _, word := range []string{"ikke", "sjølv", "blåbærsyltetøy"} {
+		id, _ := db.AddBannedWordPending(word, "", "melder", "Melder", "", "")
+		service.PostPendingBannedWordToRettingChannel(id)
+	}
+
+	want := []string{"📕 Berre bokmål", "✅ Finst i nynorsk ordliste", "❓ Ukjend"}
+	embeds := discord.SentEmbeds(bottest.RettingChannelID)
+	if len(embeds) != len(want) {
+		t.Fatalf("posted %d embeds, want %d", len(embeds), len(want))
+	}
+	for i, embed := range embeds {
+		last := embed.Fields[len(embed.Fields)-1]
+		if last.Name != "📚 Ordbok" || last.Value != want[i] {
+			t.Errorf("%s: dictionary field = %+v, want %q", embed.Title, last, want[i])
+		}
+	}
+}
+
+func TestRettingPostWithoutDictionary(t *testing.T) {
+	b, discord, db := bottest.New()
+	id, _ := db.AddBannedWordPending("ikke", "", "melder", "Melder", "", "")
+
+	(&ApprovalService{Bot: b}).PostPendingBannedWordToRettingChannel(id)
+
+	embeds := discord.SentEmbeds(bottest.RettingChannelID)
+	if len(embeds) != 1 {
+		t.Fatalf("posted %d embeds, want 1", len(embeds))
+	}
+	for _, field := range embeds[0].Fields {
+		if field.Name == "📚 Ordbok" {
+			t.Errorf("dictionary field shown without a dictionary: %+v", field)
+		}
+	}
+	if seeded := discord.Requests("PUT", "/channels/"+bottest.RettingChannelID+"/messages/"); len(seeded) != 2 ||
+		!strings.Contains(seeded[0].Path, "/reactions/") || !strings.Contains(seeded[1].Path, "/reactions/") {
+		t.Errorf("expected 👍 and 👎 to be seeded, got %+v", seeded)
+	}
+}
@@ -2,9 +2,11 @@ package services
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"askeladden/internal/database"
+	"askeladden/internal/dictionary"
 	"github.com/bwmarrin/discordgo"
 )
 
@@ -16,6 +18,7 @@ const (
 	ColorInfo      = 0x0099ff // Blue
 	ColorPrimary   = 0x7289da // Discord Blurple
 	ColorStarboard = 0xFFD700 // Gold
+	ColorNeutral   = 0x99aab5 // Grey
 )
 
 // EmbedType represents different types of embeds
@@ -228,48 +231,212 @@ func CreateApprovalEmbed(title, description string, author *discordgo.User) *dis
 	return builder.Build()
 }
 
-// CreateBannedWordWarningEmbed creates standardized banned word warning embeds
-func CreateBannedWordWarningEmbed(bannedWords []string, forumThreads []string) *discordgo.MessageEmbed {
+// CreateBannedWordRejectionEmbed creates the retting channel embed for a rejected
+// or withdrawn banned word report
+func CreateBannedWordRejectionEmbed(bannedWord *database.BannedWord, reporter *discordgo.User) *discordgo.MessageEmbed {
+	rejectedBy := ""
+	if bannedWord.RejectedBy != nil {
+		rejectedBy = *bannedWord.RejectedBy
+	}
+
+	var description string
+	if rejectedBy == bannedWord.AuthorID {
+		description = fmt.Sprintf("↩️ Trekt tilbake av melderen <@%s>", rejectedBy)
+	} else {
+		description = fmt.Sprintf("❌ Avvist av <@%s>", rejectedBy)
+	}
+	if bannedWord.RejectionReason != nil && *bannedWord.RejectionReason != "" {
+		description += fmt.Sprintf("\n\n**Grunngiving:** %s", *bannedWord.RejectionReason)
+	}
+
+	builder := NewEmbedBuilder().
+		SetTitle(bannedWord.Word).
+		SetDescription(description).
+		SetColor(ColorNeutral)
+
+	if reporter != nil {
+		builder.SetAuthorFromUser(reporter)
+	} else {
+		builder.SetAuthor(bannedWord.AuthorName, "")
+	}
+
+	return builder.Build()
+}
+
+// CreateUnbanProposalEmbed creates the retting channel embed for a proposal to
+// lift a ban. The status text and color follow the approval progress.
+func CreateUnbanProposalEmbed(bannedWord *database.BannedWord, proposal *database.UnbanProposal, status string, color int, proposer *discordgo.User) *discordgo.MessageEmbed {
+	builder := NewEmbedBuilder().
+		SetTitle("🔓 Opphev: " + bannedWord.Word).
+		SetDescription(status).
+		SetColor(color)
+
+	if proposal.Reason != "" {
+		builder.AddField("📝 Grunngiving", proposal.Reason, false)
+	}
+
+	if proposer != nil {
+		builder.SetAuthorFromUser(proposer)
+	} else {
+		builder.SetAuthor(proposal.ProposerName, "")
+	}
+
+	return builder.Build()
+}
+
+// warningTone is the title, heading and colour of a warning at one severity
+type warningTone struct {
+	title     string
+	heading   string
+	embedType EmbedType
+}
+
+// warningTones gives low severity words a gentle hint and high severity words a proper warning
+var warningTones = map[string]warningTone{
+	database.SeverityLow:    {"💡 Språktips", "💡 **Eit lite tips**", EmbedTypeInfo},
+	database.SeverityMedium: {"📝 Språkrettleiing", "⚠️ **Grammatisk merknad**", EmbedTypeWarning},
+	database.SeverityHigh:   {"🚫 Språkåtvaring", "🚫 **Åtvaring**", EmbedTypeError},
+}
+
+// categoryExplanations explains a single word of each category in a warning
+var categoryExplanations = map[string]string{
+	database.CategoryBokmal:    "Ordet **\"%s\"** er markert som feilaktig i norsk.",
+	database.CategoryAnglicism: "**\"%s\"** er ein anglisisme.",
+	database.CategoryCompound:  "**\"%s\"** er særskrive. Samansette ord skal skrivast i eitt ord.",
+	database.CategorySidemal:   "**\"%s\"** er ei sidemålsform. Ho er tillaten i nokre samanhengar, men passar ikkje i nynorsk tekst.",
+}
+
+// CategoryLabel returns the name of a banned word category for display
+func CategoryLabel(category string) string {
+	switch category {
+	case database.CategoryAnglicism:
+		return "Anglisisme"
+	case database.CategoryCompound:
+		return "Særskriving"
+	case database.CategorySidemal:
+		return "Sidemålsform"
+	default:
+		return "Bokmålsform"
+	}
+}
+
+// SeverityLabel returns the name of a severity for display
+func SeverityLabel(severity string) string {
+	switch severity {
+	case database.SeverityLow:
+		return "låg"
+	case database.SeverityHigh:
+		return "høg"
+	default:
+		return "middels"
+	}
+}
+
+// CategoryFieldName is the name of the field added by BannedWordCategoryField
+const CategoryFieldName = "🏷️ Kategori"
+
+// BannedWordCategoryField shows the category and severity of a banned word
+func BannedWordCategoryField(bannedWord *database.BannedWord) *discordgo.MessageEmbedField {
+	return &discordgo.MessageEmbedField{
+		Name:  CategoryFieldName,
+		Value: fmt.Sprintf("%s • %s alvor", CategoryLabel(bannedWord.Category), SeverityLabel(bannedWord.Severity)),
+	}
+}
+
+// DictionaryLabel describes what the dictionary knows about a word
+func DictionaryLabel(status dictionary.Status) string {
+	switch status {
+	case dictionary.Nynorsk:
+		return "✅ Finst i nynorsk ordliste"
+	case dictionary.BokmalOnly:
+		return "📕 Berre bokmål"
+	default:
+		return "❓ Ukjend"
+	}
+}
+
+// DictionaryField shows whether a reported word is nynorsk, so approvers don't
+// have to look it up by hand. Returns nil if no dictionary is loaded.
+func DictionaryField(dict *dictionary.Dictionary, word string) *discordgo.MessageEmbedField {
+	if dict == nil {
+		return nil
+	}
+	return &discordgo.MessageEmbedField{
+		Name:  "📚 Ordbok",
+		Value: DictionaryLabel(dict.Lookup(word)),
+	}
+}
+
+// CreateBannedWordWarningEmbed creates standardized banned word warning embeds,
+// listing the suggested alternatives for each word where there are any. The
+// strictest severity among the words sets the tone, and the category of each
+// word sets how it is explained.
+func CreateBannedWordWarningEmbed(bannedWords []*database.BannedWord) *discordgo.MessageEmbed {
+	tone := warningTones[database.HighestSeverity(bannedWords)]
+
 	var warningText string
 	if len(bannedWords) == 1 {
-		warningText = fmt.Sprintf("⚠️ **Grammatisk merknad**\n\nOrdet **\"%s\"** er markert som feilaktig i norsk.", bannedWords[0])
+		bw := bannedWords[0]
+		explanation, ok := categoryExplanations[bw.Category]
+		if !ok {
+			explanation = categoryExplanations[database.CategoryBokmal]
+		}
+		warningText = tone.heading + "\n\n" + fmt.Sprintf(explanation, bw.Word)
+		if len(bw.Suggestions) > 0 {
+			warningText += fmt.Sprintf("\nBruk heller: %s", formatSuggestions(bw.Suggestions))
+		}
 	} else {
-		warningText = fmt.Sprintf("⚠️ **Grammatisk merknad**\n\nDesse orda er markerte som feilaktige i norsk: **%s**",
-			fmt.Sprintf("%v", bannedWords))
+		warningText = tone.heading + "\n\nDesse orda er markerte som feilaktige i norsk:"
+		for _, bw := range bannedWords {
+			line := fmt.Sprintf("\n• **%s**", bw.Word)
+			if bw.Category != "" && bw.Category != database.CategoryBokmal {
+				line += fmt.Sprintf(" (%s)", strings.ToLower(CategoryLabel(bw.Category)))
+			}
+			if len(bw.Suggestions) > 0 {
+				line += " → " + formatSuggestions(bw.Suggestions)
+			}
+			warningText += line
+		}
 	}
 
-	// Add forum thread references if available
-	if len(forumThreads) > 0 {
-		// Remove duplicates
-		uniqueThreads := make(map[string]bool)
-		var uniqueThreadList []string
-		for _, threadID := range forumThreads {
-			if !uniqueThreads[threadID] {
-				uniqueThreads[threadID] = true
-				uniqueThreadList = append(uniqueThreadList, threadID)
-			}
+	// Add forum thread references if available, without duplicates
+	uniqueThreads := make(map[string]bool)
+	var uniqueThreadList []string
+	for _, bw := range bannedWords {
+		if bw.ForumThreadID != nil && *bw.ForumThreadID != "" && !uniqueThreads[*bw.ForumThreadID] {
+			uniqueThreads[*bw.ForumThreadID] = true
+			uniqueThreadList = append(uniqueThreadList, *bw.ForumThreadID)
 		}
+	}
 
-		if len(uniqueThreadList) == 1 {
-			warningText += fmt.Sprintf("\n\nSjå diskusjon: <#%s>", uniqueThreadList[0])
-		} else if len(uniqueThreadList) > 1 {
-			threadLinks := make([]string, len(uniqueThreadList))
-			for i, threadID := range uniqueThreadList {
-				threadLinks[i] = fmt.Sprintf("<#%s>", threadID)
-			}
-			warningText += fmt.Sprintf("\n\nSjå diskusjonar: %s", fmt.Sprintf("%v", threadLinks))
+	if len(uniqueThreadList) == 1 {
+		warningText += fmt.Sprintf("\n\nSjå diskusjon: <#%s>", uniqueThreadList[0])
+	} else if len(uniqueThreadList) > 1 {
+		threadLinks := make([]string, len(uniqueThreadList))
+		for i, threadID := range uniqueThreadList {
+			threadLinks[i] = fmt.Sprintf("<#%s>", threadID)
 		}
+		warningText += fmt.Sprintf("\n\nSjå diskusjonar: %s", strings.Join(threadLinks, " "))
 	} else {
 		warningText += "\n\nSjå grammatikkforumet for meir informasjon."
 	}
 
 	return NewEmbedBuilder().
-		SetTitle("📝 Språkrettleiing").
+		SetTitle(tone.title).
 		SetDescription(warningText).
-		SetColorByType(EmbedTypeWarning).
+		SetColorByType(tone.embedType).
 		Build()
 }
 
+// formatSuggestions formats suggested alternatives as a bold, comma-separated list
+func formatSuggestions(suggestions []string) string {
+	formatted := make([]string, len(suggestions))
+	for i, suggestion := range suggestions {
+		formatted[i] = "**" + suggestion + "**"
+	}
+	return strings.Join(formatted, ", ")
+}
+
 // CreateStarboardEmbed creates standardized starboard embeds
 func CreateStarboardEmbed(msg *discordgo.Message, stars int, channelName, emoji, guildID string) *discordgo.MessageEmbed {
 	builder := NewEmbedBuilder().
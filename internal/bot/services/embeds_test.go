@@ -0,0 +1,57 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"askeladden/internal/database"
+)
+
+func TestBannedWordWarningListsSuggestions(t *testing.T) {
+	thread := "tråd"
+	single := CreateBannedWordWarningEmbed([]*database.BannedWord{
+		{Word: "ikke", Suggestions: []string{"ikkje"}, ForumThreadID: &thread},
+	})
+	if !strings.Contains(single.Description, "Bruk heller: **ikkje**") || !strings.Contains(single.Description, "<#tråd>") {
+		t.Errorf("single word warning = %q", single.Description)
+	}
+
+	multiple := CreateBannedWordWarningEmbed([]*database.BannedWord{
+		{Word: "hvordan", Suggestions: []string{"korleis", "kor"}},
+		{Word: "noen"},
+	})
+	for _, want := range []string{"• **hvordan** → **korleis**, **kor**", "• **noen**", "grammatikkforumet"} {
+		if !strings.Contains(multiple.Description, want) {
+			t.Errorf("multiple word warning is missing %q:\n%s", want, multiple.Description)
+		}
+	}
+}
+
+func TestBannedWordWarningToneFollowsSeverity(t *testing.T) {
+	hint := CreateBannedWordWarningEmbed([]*database.BannedWord{
+		{Word: "nogen", Category: database.CategorySidemal, Severity: database.SeverityLow},
+	})
+	if hint.Title != "💡 Språktips" || hint.Color != ColorInfo || !strings.Contains(hint.Description, "sidemålsform") {
+		t.Errorf("low severity warning = %q / %x / %q", hint.Title, hint.Color, hint.Description)
+	}
+
+	// The strictest word sets the tone, and each word shows its category
+	mixed := CreateBannedWordWarningEmbed([]*database.BannedWord{
+		{Word: "nogen", Category: database.CategorySidemal, Severity: database.SeverityLow},
+		{Word: "weekend", Category: database.CategoryAnglicism, Severity: database.SeverityHigh},
+	})
+	if mixed.Title != "🚫 Språkåtvaring" || mixed.Color != ColorError {
+		t.Errorf("mixed warning = %q / %x", mixed.Title, mixed.Color)
+	}
+	for _, want := range []string{"• **nogen** (sidemålsform)", "• **weekend** (anglisisme)"} {
+		if !strings.Contains(mixed.Description, want) {
+			t.Errorf("mixed warning is missing %q:\n%s", want, mixed.Description)
+		}
+	}
+
+	// Words without a severity get the usual grammar note
+	plain := CreateBannedWordWarningEmbed([]*database.BannedWord{{Word: "ikke"}})
+	if plain.Title != "📝 Språkrettleiing" || plain.Color != ColorWarning {
+		t.Errorf("default warning = %q / %x", plain.Title, plain.Color)
+	}
+}
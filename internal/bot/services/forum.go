@@ -0,0 +1,145 @@
+package services
+
+import (
+	"log"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+
+	"askeladden/internal/database"
+)
+
+// StartForumThread creates the discussion thread for a reported banned word in
+// the grammar forum and stores it on the word. The thread starts out tagged as
+// pending; use SyncForumThread when the word's status changes. Returns nil if
+// no thread was created.
+func (s *ApprovalService) StartForumThread(session *discordgo.Session, bannedWord *database.BannedWord, guildID string) *discordgo.Channel {
+	originalChannelID, originalMessageID := splitOriginalMessageID(bannedWord.OriginalMessageID)
+	thread := s.PostBannedWordReport(session, []string{bannedWord.Word}, bannedWord.AuthorID, guildID, originalChannelID, originalMessageID)
+	if thread == nil {
+		return nil
+	}
+
+	log.Printf("Created forum thread %s for banned word %s", thread.ID, bannedWord.Word)
+	if err := s.Bot.Database.UpdateBannedWordForumThreadID(bannedWord.ID, thread.ID); err != nil {
+		log.Printf("Failed to store forum thread for banned word %d: %v", bannedWord.ID, err)
+	}
+	bannedWord.ForumThreadID = &thread.ID
+	return thread
+}
+
+// splitOriginalMessageID splits the "channel|message" reference to the reported
+// message. Old reports stored only the message ID.
+func splitOriginalMessageID(originalMessageID *string) (channelID, messageID string) {
+	if originalMessageID == nil {
+		return "", ""
+	}
+	if channelID, messageID, ok := strings.Cut(*originalMessageID, "|"); ok {
+		return channelID, messageID
+	}
+	return "", *originalMessageID
+}
+
+// SyncForumThread sets the status tags on a banned word's forum thread, so the
+// grammar forum can be filtered by status. When the ban has been lifted, the
+// thread is also locked and archived.
+func (s *ApprovalService) SyncForumThread(session *discordgo.Session, bannedWord *database.BannedWord) {
+	if bannedWord.ForumThreadID == nil || *bannedWord.ForumThreadID == "" {
+		return
+	}
+	threadID := *bannedWord.ForumThreadID
+
+	thread, err := session.Channel(threadID)
+	if err != nil {
+		log.Printf("Failed to get forum thread %s: %v", threadID, err)
+		return
+	}
+
+	// Keep tags set by hand, and replace only the status tags
+	statusTags := make(map[string]bool)
+	for _, id := range s.forumTagIDs(session, s.Bot.Config.Grammar.Tags.StatusTags()) {
+		statusTags[id] = true
+	}
+	tags := []string{}
+	for _, id := range thread.AppliedTags {
+		if !statusTags[id] {
+			tags = append(tags, id)
+		}
+	}
+	tags = append(tags, s.forumTagIDs(session, s.forumStatusTags(bannedWord))...)
+
+	edit := &discordgo.ChannelEdit{AppliedTags: &tags}
+	closed := bannedWord.UnbannedAt != nil
+	archived := thread.ThreadMetadata != nil && thread.ThreadMetadata.Archived
+	locked := thread.ThreadMetadata != nil && thread.ThreadMetadata.Locked
+	if closed {
+		edit.Locked = &closed
+		edit.Archived = &closed
+	} else {
+		// Archived threads can't be edited without opening them again, and
+		// the thread of a word that is reported again is opened for discussion
+		if archived {
+			edit.Archived = &closed
+		}
+		if locked {
+			edit.Locked = &closed
+		}
+	}
+
+	if _, err := session.ChannelEditComplex(threadID, edit); err != nil {
+		log.Printf("Failed to update tags on forum thread %s: %v", threadID, err)
+	}
+}
+
+// forumStatusTags returns the names of the status tags a banned word's thread
+// should have. An approved word with a pending proposal to lift the ban gets
+// both the approved and the pending tag.
+func (s *ApprovalService) forumStatusTags(bannedWord *database.BannedWord) []string {
+	tags := s.Bot.Config.Grammar.Tags
+	switch {
+	case bannedWord.UnbannedAt != nil:
+		return []string{tags.UnbannedTag()}
+	case bannedWord.ApprovalStatus == "rejected":
+		return []string{tags.RejectedTag()}
+	case bannedWord.ApprovalStatus == "fully_approved":
+		proposal, err := s.Bot.Database.GetPendingUnbanProposalForWord(bannedWord.ID)
+		if err != nil {
+			log.Printf("Failed to check pending unban proposals for banned word %d: %v", bannedWord.ID, err)
+		}
+		if proposal != nil {
+			return []string{tags.ApprovedTag(), tags.PendingTag()}
+		}
+		return []string{tags.ApprovedTag()}
+	default:
+		return []string{tags.PendingTag()}
+	}
+}
+
+// forumTagIDs looks up tags in the grammar forum by name. Names the forum
+// doesn't have are logged and left out.
+func (s *ApprovalService) forumTagIDs(session *discordgo.Session, names []string) []string {
+	forumID := s.Bot.Config.Grammar.ChannelID
+	forum, err := session.State.Channel(forumID)
+	if err != nil {
+		if forum, err = session.Channel(forumID); err != nil {
+			log.Printf("Failed to get grammar forum %s: %v", forumID, err)
+			return nil
+		}
+	}
+
+	var ids []string
+	for _, name := range names {
+		found := false
+		for _, tag := range forum.AvailableTags {
+			if tag.Name == name {
+				ids = append(ids, tag.ID)
+				found = true
+				break
+			}
+		}
+		if !found {
+			log.Printf("Grammar forum has no tag named %q", name)
+		}
+	}
+	return ids
+}
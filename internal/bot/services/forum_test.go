@@ -0,0 +1,56 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+
+	"askeladden/internal/bot/bottest"
+)
+
+func TestStartForumThreadIsPending(t *testing.T) {
+	b, discord, db := bottest.New()
+	discord.Respond("POST", "/channels/"+bottest.GrammarChannelID+"/threads", &discordgo.Channel{ID: "traad", Name: "ikke"})
+	id, _ := db.AddBannedWordPending("ikke", "", "melder", "melder", "", "c|m")
+	bw, _ := db.GetBannedWordByID(int(id))
+
+	if thread := (&ApprovalService{Bot: b}).StartForumThread(b.Session, bw, bottest.GuildID); thread == nil {
+		t.Fatal("no thread created")
+	}
+
+	var start struct {
+		AppliedTags []string `json:"applied_tags"`
+	}
+	discord.Requests("POST", "/channels/"+bottest.GrammarChannelID+"/threads")[0].Decode(&start)
+	if len(start.AppliedTags) != 1 || start.AppliedTags[0] != "tag-ventar" {
+		t.Errorf("applied tags = %v, want ventar", start.AppliedTags)
+	}
+	if stored, _ := db.GetBannedWordByID(int(id)); stored.ForumThreadID == nil || *stored.ForumThreadID != "traad" {
+		t.Errorf("thread id not stored: %+v", stored)
+	}
+}
+
+func TestSyncForumThreadReopensArchivedThread(t *testing.T) {
+	b, discord, db := bottest.New()
+	id, _ := db.AddBannedWordPending("ikke", "", "u1", "brukar", "traad", "")
+	db.RejectBannedWord(int(id), "rett")
+	discord.Respond("GET", "/channels/traad", &discordgo.Channel{
+		ID:             "traad",
+		AppliedTags:    []string{"tag-ventar"},
+		ThreadMetadata: &discordgo.ThreadMetadata{Archived: true},
+	})
+
+	bw, _ := db.GetBannedWordByID(int(id))
+	(&ApprovalService{Bot: b}).SyncForumThread(b.Session, bw)
+
+	edits := discord.ChannelEdits("traad")
+	if len(edits) != 1 {
+		t.Fatalf("thread edited %d times, want 1", len(edits))
+	}
+	if tags := *edits[0].AppliedTags; len(tags) != 1 || tags[0] != "tag-avvist" {
+		t.Errorf("tags = %v, want avvist", tags)
+	}
+	if edits[0].Archived == nil || *edits[0].Archived {
+		t.Error("archived thread was not opened for the edit")
+	}
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"log"
+	"time"
 
 	"askeladden/internal/bot"
 	"askeladden/internal/database"
@@ -9,13 +10,15 @@ import (
 )
 
 // SendDailyQuestion sends the daily question to the appropriate channel
-// mention may be "@everyone", "<@user_id>", or blank
-func SendDailyQuestion(bot *bot.Bot, question *database.Question, mention string) {
+// mention may be "@everyone", "<@user_id>", or blank. Returns the posted
+// message, or nil if it couldn't be sent. If answer threads are enabled, the
+// thread started on the message is set as its Thread.
+func SendDailyQuestion(bot *bot.Bot, question *database.Question, mention string) *discordgo.Message {
 	// Use configured default channel ID instead of hardcoded
 	channelID := bot.Config.Discord.DefaultChannelID
 	if channelID == "" {
 		log.Printf("[MESSAGING] No default channel ID configured, cannot send daily question")
-		return
+		return nil
 	}
 
 	// Try to fetch pretty channel name
@@ -34,8 +37,50 @@ func SendDailyQuestion(bot *bot.Bot, question *database.Question, mention string
 		Embeds:  []*discordgo.MessageEmbed{embed},
 	}
 	log.Printf("[MESSAGING] Sending daily question to %s for %s: \"%s\" [mention:'%s']", channelName, embed.Author.Name, question.Question, mention)
-	_, err := bot.Session.ChannelMessageSendComplex(channelID, msg)
+	sent, err := bot.Session.ChannelMessageSendComplex(channelID, msg)
 	if err != nil {
 		log.Printf("[MESSAGING] Failed to send daily question: %v", err)
+		return nil
+	}
+
+	if bot.Config.Scheduler.AnswerThreads.Enabled {
+		sent.Thread = startAnswerThread(bot, question, sent)
+	}
+	return sent
+}
+
+// startAnswerThread starts a public thread for the answers on a posted daily
+// question. Returns nil if the thread couldn't be started; the question is
+// still posted.
+func startAnswerThread(bot *bot.Bot, question *database.Question, sent *discordgo.Message) *discordgo.Channel {
+	settings := bot.Config.Scheduler.AnswerThreads
+	now := time.Now()
+	if timezone, err := time.LoadLocation(bot.Config.Scheduler.Timezone); err == nil {
+		now = now.In(timezone)
+	}
+
+	thread, err := bot.Session.MessageThreadStartComplex(sent.ChannelID, sent.ID, &discordgo.ThreadStart{
+		Name:                settings.Name(question.Question, now),
+		AutoArchiveDuration: settings.AutoArchiveDuration(),
+	})
+	if err != nil {
+		log.Printf("[MESSAGING] Failed to start answer thread for daily question %d: %v", question.ID, err)
+		return nil
+	}
+	return thread
+}
+
+// RecordQuestionPost adds a posted daily question to the question history.
+// triggeredBy is the admin behind a !poke, or empty.
+func RecordQuestionPost(bot *bot.Bot, question *database.Question, sent *discordgo.Message, trigger, triggeredBy string) {
+	if sent == nil {
+		return
+	}
+	threadID := ""
+	if sent.Thread != nil {
+		threadID = sent.Thread.ID
+	}
+	if _, err := bot.Database.AddQuestionPost(question.ID, sent.ID, threadID, sent.ChannelID, trigger, triggeredBy); err != nil {
+		log.Printf("[MESSAGING] Failed to record question post for question %d: %v", question.ID, err)
 	}
 }
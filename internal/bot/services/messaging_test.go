@@ -0,0 +1,57 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"askeladden/internal/bot/bottest"
+	"askeladden/internal/database"
+)
+
+func TestSendDailyQuestionStartsAnswerThread(t *testing.T) {
+	b, discord, db := bottest.New()
+	b.Config.Scheduler.AnswerThreads.Enabled = true
+	b.Config.Scheduler.AnswerThreads.NameTemplate = "{date}: {question}"
+	b.Config.Scheduler.AnswerThreads.AutoArchiveMinutes = 4320
+	question := &database.Question{ID: 7, Question: "Kva   et du\ntil frukost?", AuthorID: "forfattar"}
+
+	sent := SendDailyQuestion(b, question, "")
+	if sent == nil || sent.Thread == nil {
+		t.Fatalf("sent = %+v", sent)
+	}
+
+	starts := discord.Requests("POST", "/channels/"+bottest.DefaultChannelID+"/messages/"+sent.ID+"/threads")
+	if len(starts) != 1 {
+		t.Fatalf("thread starts = %+v", starts)
+	}
+	var start struct {
+		Name                string `json:"name"`
+		AutoArchiveDuration int    `json:"auto_archive_duration"`
+	}
+	starts[0].Decode(&start)
+	if !strings.HasSuffix(start.Name, ": Kva et du til frukost?") || start.AutoArchiveDuration != 4320 {
+		t.Errorf("thread start = %+v", start)
+	}
+
+	RecordQuestionPost(b, question, sent, database.QuestionTriggerMorning, "")
+	posts, _ := db.GetQuestionPosts(0, 1)
+	if len(posts) != 1 || posts[0].ThreadID == nil || *posts[0].ThreadID != sent.Thread.ID || posts[0].TriggeredBy != nil {
+		t.Errorf("recorded post = %+v", posts)
+	}
+}
+
+func TestSendDailyQuestionWithoutAnswerThread(t *testing.T) {
+	b, discord, db := bottest.New()
+	question := &database.Question{ID: 7, Question: "Spørsmål?", AuthorID: "forfattar"}
+
+	sent := SendDailyQuestion(b, question, "")
+	RecordQuestionPost(b, question, sent, database.QuestionTriggerMorning, "")
+
+	if starts := discord.Requests("POST", "/channels/"+bottest.DefaultChannelID+"/messages/"); len(starts) != 0 {
+		t.Errorf("thread started: %+v", starts)
+	}
+	posts, _ := db.GetQuestionPosts(0, 1)
+	if len(posts) != 1 || posts[0].ThreadID != nil {
+		t.Errorf("recorded post = %+v", posts)
+	}
+}
@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"log"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+
+	"askeladden/internal/bot"
+	"askeladden/internal/bot/services"
+	"askeladden/internal/database"
+)
+
+func init() {
+	commands["åtvaringar"] = Command{
+		name:        "åtvaringar",
+		description: "Vel om du vil ha åtvaringar om forbodne ord i kanalen, på DM eller ikkje i det heile",
+		emoji:       "🔕",
+		handler:     Atvaringar,
+		aliases:     []string{"atvaringar"},
+	}
+}
+
+const atvaringarUsage = "Bruk: `!åtvaringar offentleg`, `!åtvaringar dm` eller `!åtvaringar av`"
+
+// warningModeArgs maps command arguments to warning modes
+var warningModeArgs = map[string]string{
+	"offentleg": database.WarningModePublic,
+	"offentlig": database.WarningModePublic,
+	"public":    database.WarningModePublic,
+	"dm":        database.WarningModeDM,
+	"privat":    database.WarningModeDM,
+	"av":        database.WarningModeOff,
+	"off":       database.WarningModeOff,
+}
+
+// warningModeDescriptions describes each mode for the user
+var warningModeDescriptions = map[string]string{
+	database.WarningModePublic: "📢 Du får åtvaringar som svar på meldinga di i kanalen.",
+	database.WarningModeDM:     "📬 Du får åtvaringar som privatmelding.",
+	database.WarningModeOff:    "🔕 Du får ingen åtvaringar om forbodne ord.",
+}
+
+// Atvaringar handsamar åtvaringar-kommandoen
+func Atvaringar(s *discordgo.Session, m *discordgo.MessageCreate, bot *bot.Bot) {
+	parts := strings.Fields(m.Content)
+
+	// No argument: show the current choice
+	if len(parts) < 2 {
+		mode, err := bot.Database.GetUserWarningMode(m.Author.ID)
+		if err != nil {
+			embed := services.CreateBotEmbed(s, "❌ Feil", "Kunne ikkje hente valet ditt.", services.EmbedTypeError)
+			s.ChannelMessageSendEmbed(m.ChannelID, embed)
+			return
+		}
+		embed := services.CreateBotEmbed(s, "🔔 Åtvaringar", warningModeDescriptions[mode]+"\n\n"+atvaringarUsage, services.EmbedTypeInfo)
+		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		return
+	}
+
+	mode, ok := warningModeArgs[strings.ToLower(parts[1])]
+	if !ok {
+		embed := services.CreateBotEmbed(s, "❓ Feil", atvaringarUsage, services.EmbedTypeError)
+		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		return
+	}
+
+	if err := bot.Database.SetUserWarningMode(m.Author.ID, mode); err != nil {
+		log.Printf("Failed to set warning mode for %s: %v", m.Author.ID, err)
+		embed := services.CreateBotEmbed(s, "❌ Feil", "Kunne ikkje lagre valet ditt.", services.EmbedTypeError)
+		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		return
+	}
+
+	embed := services.CreateBotEmbed(s, "✅ Lagra", warningModeDescriptions[mode], services.EmbedTypeSuccess)
+	s.ChannelMessageSendEmbed(m.ChannelID, embed)
+}
@@ -0,0 +1,31 @@
+package commands
+
+import (
+	"testing"
+
+	"askeladden/internal/bot/bottest"
+	"askeladden/internal/database"
+)
+
+func TestAtvaringarSetsMode(t *testing.T) {
+	b, discord, db := bottest.New()
+
+	for _, tt := range []struct{ arg, want string }{
+		{"dm", database.WarningModeDM},
+		{"AV", database.WarningModeOff},
+		{"offentleg", database.WarningModePublic},
+	} {
+		Atvaringar(b.Session, newMessage("!åtvaringar "+tt.arg, "kanal"), b)
+		if mode, _ := db.GetUserWarningMode("admin"); mode != tt.want {
+			t.Errorf("after %q mode = %q, want %q", tt.arg, mode, tt.want)
+		}
+	}
+
+	Atvaringar(b.Session, newMessage("!åtvaringar kanskje", "kanal"), b)
+	if mode, _ := db.GetUserWarningMode("admin"); mode != database.WarningModePublic {
+		t.Errorf("unknown argument changed the mode to %q", mode)
+	}
+	if embeds := discord.SentEmbeds("kanal"); len(embeds) != 4 || embeds[3].Title != "❓ Feil" {
+		t.Errorf("embeds = %+v", embeds)
+	}
+}
@@ -5,6 +5,8 @@ import (
 
 	"askeladden/internal/bot"
 	"askeladden/internal/bot/services"
+	"askeladden/internal/database"
+	"askeladden/internal/questions"
 	"github.com/bwmarrin/discordgo"
 )
 
@@ -67,11 +69,17 @@ func handleConfigCommand(s *discordgo.Session, m *discordgo.MessageCreate, b *bo
 	configInfo += "• Reject: 👎\n\n"
 
 	configInfo += "**Database Settings:**\n"
-	configInfo += fmt.Sprintf("• Host: %s\n", cfg.Database.Host)
-	configInfo += fmt.Sprintf("• Port: %d\n", cfg.Database.Port)
-	configInfo += fmt.Sprintf("• Database: %s\n", cfg.Database.DBName)
-	configInfo += fmt.Sprintf("• User: %s\n", cfg.Database.User)
-	configInfo += fmt.Sprintf("• Password: %s\n\n", mask)
+	if cfg.Database.Driver == database.DriverSQLite {
+		configInfo += "• Driver: sqlite\n"
+		configInfo += fmt.Sprintf("• Path: %s\n\n", cfg.Database.Path)
+	} else {
+		configInfo += "• Driver: mysql\n"
+		configInfo += fmt.Sprintf("• Host: %s\n", cfg.Database.Host)
+		configInfo += fmt.Sprintf("• Port: %d\n", cfg.Database.Port)
+		configInfo += fmt.Sprintf("• Database: %s\n", cfg.Database.DBName)
+		configInfo += fmt.Sprintf("• User: %s\n", cfg.Database.User)
+		configInfo += fmt.Sprintf("• Password: %s\n\n", mask)
+	}
 
 	if cfg.Environment != "" {
 		configInfo += fmt.Sprintf("\n**Environment Settings:**\n• Mode: %s", cfg.Environment)
@@ -87,6 +95,14 @@ func handleConfigCommand(s *discordgo.Session, m *discordgo.MessageCreate, b *bo
 			cfg.Scheduler.MorningTime,
 			cfg.Scheduler.EveningTime,
 			cfg.Scheduler.InactivityHours)
+		strategy := questions.FromConfig(cfg)
+		configInfo += fmt.Sprintf("\n• Question Strategy: `%s`", strategy.Name())
+		if cooldown, ok := strategy.(questions.Cooldown); ok {
+			configInfo += fmt.Sprintf(" (%d days)", cooldown.Days)
+		}
+		if threads := cfg.Scheduler.AnswerThreads; threads.Enabled {
+			configInfo += fmt.Sprintf("\n• Answer Threads: ✅ archived after %d minutes", threads.AutoArchiveDuration())
+		}
 		if cfg.Scheduler.CronString != "" {
 			configInfo += fmt.Sprintf("\n• Fallback Cron: `%s`", cfg.Scheduler.CronString)
 		}
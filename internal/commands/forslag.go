@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"log"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+
+	"askeladden/internal/bot"
+	"askeladden/internal/bot/services"
+	"askeladden/internal/permissions"
+)
+
+func init() {
+	commands["forslag"] = Command{
+		name:        "forslag",
+		description: "Vis eller set nynorske alternativ til eit forbode ord (rettskrivarar)",
+		emoji:       "💡",
+		handler:     Forslag,
+		aliases:     []string{"alternativ"},
+	}
+}
+
+const forslagUsage = "Bruk: `!forslag <ord>` for å sjå forslaga, `!forslag <ord> <alternativ>, <alternativ>, ...` for å setje dei, eller `!forslag <ord> -` for å fjerne dei.\n\n" +
+	"Døme: `!forslag ikke ikkje` eller `!forslag hvordan korleis, kor`"
+
+// Forslag handsamar forslag-kommandoen
+func Forslag(s *discordgo.Session, m *discordgo.MessageCreate, bot *bot.Bot) {
+	parts := strings.Fields(m.Content)
+	if len(parts) < 2 {
+		embed := services.CreateBotEmbed(s, "❓ Feil", forslagUsage, services.EmbedTypeError)
+		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		return
+	}
+
+	bannedWord := findReportedWord(s, m, bot, parts[1])
+	if bannedWord == nil {
+		return
+	}
+
+	// Only the word: anyone may see the current suggestions
+	if len(parts) == 2 {
+		embed := services.CreateBotEmbed(s, "💡 Forslag for «"+bannedWord.Word+"»", formatSuggestionList(bannedWord.Suggestions), services.EmbedTypeInfo)
+		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		return
+	}
+
+	permManager := permissions.NewPermissionManager(bot.Config)
+	if !permManager.HasRettskrivarRole(s, m.GuildID, m.Author.ID) {
+		embed := services.CreateBotEmbed(s, "⛔ Inga tilgang", "Berre rettskrivarar kan endre forslag.", services.EmbedTypeError)
+		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		return
+	}
+
+	var suggestions []string
+	list := strings.Join(parts[2:], " ")
+	if list != "-" {
+		for _, suggestion := range strings.Split(list, ",") {
+			if suggestion = strings.Join(strings.Fields(suggestion), " "); suggestion != "" {
+				suggestions = append(suggestions, suggestion)
+			}
+		}
+	}
+
+	if err := bot.Database.UpdateBannedWordSuggestions(bannedWord.ID, suggestions); err != nil {
+		log.Printf("Failed to update suggestions for banned word %d: %v", bannedWord.ID, err)
+		embed := services.CreateBotEmbed(s, "❌ Feil", "Kunne ikkje lagre forslaga.", services.EmbedTypeError)
+		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		return
+	}
+	// The warnings read suggestions from the cached words
+	bot.BannedWords.Invalidate()
+
+	embed := services.CreateBotEmbed(s, "✅ Forslag oppdaterte for «"+bannedWord.Word+"»", formatSuggestionList(suggestions), services.EmbedTypeSuccess)
+	s.ChannelMessageSendEmbed(m.ChannelID, embed)
+}
+
+// formatSuggestionList lists suggestions for an embed description
+func formatSuggestionList(suggestions []string) string {
+	if len(suggestions) == 0 {
+		return "Ingen forslag enno."
+	}
+	return "Bruk heller: **" + strings.Join(suggestions, "**, **") + "**"
+}
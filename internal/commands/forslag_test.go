@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"askeladden/internal/bot/bottest"
+)
+
+func TestForslagSetsAndShowsSuggestions(t *testing.T) {
+	b, discord, db := bottest.New()
+	id, _ := db.AddBannedWordPending("hvordan", "", "u1", "brukar", "", "")
+	discord.SetMember("admin", bottest.RettskrivarRoleID)
+
+	Forslag(b.Session, newMessage("!forslag Hvordan korleis,  kor , ", "kanal"), b)
+
+	bw, _ := db.GetBannedWordByID(int(id))
+	if strings.Join(bw.Suggestions, "|") != "korleis|kor" {
+		t.Fatalf("suggestions = %q", bw.Suggestions)
+	}
+
+	Forslag(b.Session, newMessage("!forslag hvordan", "kanal"), b)
+	embeds := discord.SentEmbeds("kanal")
+	if len(embeds) != 2 || !strings.Contains(embeds[1].Description, "**korleis**, **kor**") {
+		t.Fatalf("embeds = %+v", embeds)
+	}
+
+	Forslag(b.Session, newMessage("!forslag hvordan -", "kanal"), b)
+	if bw, _ := db.GetBannedWordByID(int(id)); len(bw.Suggestions) != 0 {
+		t.Errorf("suggestions not cleared: %q", bw.Suggestions)
+	}
+}
+
+func TestForslagRequiresRettskrivar(t *testing.T) {
+	b, discord, db := bottest.New()
+	id, _ := db.AddBannedWordPending("hvordan", "", "u1", "brukar", "", "")
+	discord.SetMember("admin", bottest.OpplysarRoleID)
+
+	Forslag(b.Session, newMessage("!forslag hvordan korleis", "kanal"), b)
+
+	if bw, _ := db.GetBannedWordByID(int(id)); len(bw.Suggestions) != 0 {
+		t.Errorf("suggestions changed by opplysar: %q", bw.Suggestions)
+	}
+}
+
+func TestForslagUnknownWord(t *testing.T) {
+	b, discord, _ := bottest.New()
+
+	Forslag(b.Session, newMessage("!forslag ukjent korleis", "kanal"), b)
+
+	embeds := discord.SentEmbeds("kanal")
+	if len(embeds) != 1 || !strings.Contains(embeds[0].Title, "Ukjent") {
+		t.Fatalf("embeds = %+v", embeds)
+	}
+}
@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+
+	"askeladden/internal/bot/bottest"
+)
+
+// newMessage creates a MessageCreate event from an admin in the given channel
+func newMessage(content, channelID string) *discordgo.MessageCreate {
+	return &discordgo.MessageCreate{Message: &discordgo.Message{
+		ID:        "cmd",
+		Content:   content,
+		ChannelID: channelID,
+		GuildID:   bottest.GuildID,
+		Author:    &discordgo.User{ID: "admin", Username: "admin"},
+	}}
+}
+
+func TestGodkjennNext(t *testing.T) {
+	b, discord, db := bottest.New()
+	firstID, _ := db.AddQuestion("Kva et du til frukost?", "u1", "brukar", "m1", "c")
+	db.AddQuestion("Kva er favorittordet ditt?", "u2", "brukar2", "m2", "c")
+
+	Godkjenn(b.Session, newMessage("!godkjenn neste", "admin-kanal"), b)
+
+	q, _ := db.GetQuestionByMessageID("m1")
+	if q.ID != int(firstID) || q.ApprovalStatus != "approved" || q.ApprovedBy == nil || *q.ApprovedBy != "admin" {
+		t.Fatalf("first question = %+v, want approved by admin", q)
+	}
+	if other, _ := db.GetQuestionByMessageID("m2"); other.ApprovalStatus != "pending" {
+		t.Errorf("second question status = %q, want pending", other.ApprovalStatus)
+	}
+
+	embeds := discord.SentEmbeds("admin-kanal")
+	if len(embeds) != 1 || !strings.Contains(embeds[0].Title, "godkjent") {
+		t.Fatalf("confirmation embeds = %+v", embeds)
+	}
+	if dms := discord.Requests("POST", "/users/@me/channels"); len(dms) != 1 {
+		t.Errorf("expected one DM channel to notify the author, got %d", len(dms))
+	}
+}
+
+func TestGodkjennByID(t *testing.T) {
+	b, _, db := bottest.New()
+	db.AddQuestion("Første?", "u1", "brukar", "m1", "c")
+	db.AddQuestion("Andre?", "u1", "brukar", "m2", "c")
+
+	Godkjenn(b.Session, newMessage("!godkjenn 2", "admin-kanal"), b)
+
+	if q, _ := db.GetQuestionByMessageID("m2"); q.ApprovalStatus != "approved" {
+		t.Errorf("question 2 status = %q, want approved", q.ApprovalStatus)
+	}
+	if q, _ := db.GetQuestionByMessageID("m1"); q.ApprovalStatus != "pending" {
+		t.Errorf("question 1 status = %q, want pending", q.ApprovalStatus)
+	}
+}
+
+func TestGodkjennUnknownID(t *testing.T) {
+	b, discord, db := bottest.New()
+	id, _ := db.AddQuestion("Avvist?", "u1", "brukar", "m1", "c")
+	db.RejectQuestion(int(id), "opplysar")
+
+	Godkjenn(b.Session, newMessage("!godkjenn 1", "admin-kanal"), b)
+
+	if q, _ := db.GetQuestionByMessageID("m1"); q.ApprovalStatus != "rejected" {
+		t.Errorf("rejected question changed status to %q", q.ApprovalStatus)
+	}
+	embeds := discord.SentEmbeds("admin-kanal")
+	if len(embeds) != 1 || !strings.Contains(embeds[0].Description, "Kunne ikkje finne") {
+		t.Errorf("error embeds = %+v", embeds)
+	}
+}
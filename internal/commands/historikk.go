@@ -0,0 +1,110 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+
+	"askeladden/internal/bot"
+	"askeladden/internal/bot/services"
+	"askeladden/internal/database"
+)
+
+func init() {
+	commands["historikk"] = Command{
+		name:        "historikk",
+		description: "Bla i dei daglege spørsmåla som er stilte, med kvifor og kvar (kun admin)",
+		emoji:       "📜",
+		handler:     handleHistorikk,
+		adminOnly:   true,
+	}
+}
+
+const historyPageSize = 10
+
+func handleHistorikk(s *discordgo.Session, m *discordgo.MessageCreate, bot *bot.Bot) {
+	page := 1
+	if args := strings.Fields(m.Content); len(args) > 1 {
+		parsed, err := strconv.Atoi(args[1])
+		if err != nil || parsed < 1 {
+			embed := services.CreateBotEmbed(s, "❓ Feil", "Bruk: `!historikk [side]`", services.EmbedTypeError)
+			s.ChannelMessageSendEmbed(m.ChannelID, embed)
+			return
+		}
+		page = parsed
+	}
+
+	total, err := bot.Database.CountQuestionPosts()
+	if err != nil {
+		embed := services.CreateBotEmbed(s, "❌ Feil", "Kunne ikkje hente historikken.", services.EmbedTypeError)
+		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		return
+	}
+
+	builder := services.NewEmbedBuilder().
+		SetTitle("📜 Stilte spørsmål").
+		SetColorByType(services.EmbedTypeInfo).
+		SetAuthorFromBot(s)
+
+	if total == 0 {
+		builder.SetDescription("Ingen daglege spørsmål er stilte enno.")
+		s.ChannelMessageSendEmbed(m.ChannelID, builder.Build())
+		return
+	}
+
+	pages := (total + historyPageSize - 1) / historyPageSize
+	page = min(page, pages)
+	posts, err := bot.Database.GetQuestionPosts((page-1)*historyPageSize, historyPageSize)
+	if err != nil {
+		log.Printf("Failed to get question history: %v", err)
+		embed := services.CreateBotEmbed(s, "❌ Feil", "Kunne ikkje hente historikken.", services.EmbedTypeError)
+		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		return
+	}
+
+	var entries []string
+	for _, p := range posts {
+		entries = append(entries, formatQuestionPost(m.GuildID, p))
+	}
+	builder.SetDescription(strings.Join(entries, "\n\n"))
+	footer := fmt.Sprintf("Side %d av %d • %d gonger stilt", page, pages, total)
+	if page < pages {
+		footer += fmt.Sprintf(" • !historikk %d for eldre", page+1)
+	}
+	builder.SetFooter(footer, "")
+	s.ChannelMessageSendEmbed(m.ChannelID, builder.Build())
+}
+
+// formatQuestionPost describes one posted daily question in the history
+func formatQuestionPost(guildID string, p *database.QuestionPost) string {
+	question := previewText(p.Question)
+	if question == "" {
+		question = fmt.Sprintf("*spørsmål %d finst ikkje lenger*", p.QuestionID)
+	}
+	link := fmt.Sprintf("https://discord.com/channels/%s/%s/%s", guildID, p.ChannelID, p.MessageID)
+	entry := fmt.Sprintf("<t:%d:f> i <#%s> • [Til meldinga](%s)", p.PostedAt.Unix(), p.ChannelID, link)
+	if p.ThreadID != nil {
+		entry += " • 🧵 <#" + *p.ThreadID + ">"
+	}
+	return fmt.Sprintf("%s\n%s\n*%s*", entry, question, formatTrigger(p))
+}
+
+// formatTrigger says what made a daily question be posted
+func formatTrigger(p *database.QuestionPost) string {
+	switch p.Trigger {
+	case database.QuestionTriggerMorning:
+		return "🌅 Morgonposten"
+	case database.QuestionTriggerInactivity:
+		return "💤 Stille i kanalen"
+	case database.QuestionTriggerPoke:
+		if p.TriggeredBy != nil {
+			return "👉 !poke av <@" + *p.TriggeredBy + ">"
+		}
+		return "👉 !poke"
+	default:
+		return p.Trigger
+	}
+}
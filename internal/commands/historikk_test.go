@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"askeladden/internal/bot/bottest"
+	"askeladden/internal/database"
+)
+
+func TestPokeRecordsQuestionPost(t *testing.T) {
+	b, discord, db := bottest.New()
+	addApprovedQuestions(db, "Første?")
+
+	handlePoke(b.Session, newMessage("!poke", "admin-kanal"), b)
+
+	posts, _ := db.GetQuestionPosts(0, 10)
+	if len(posts) != 1 {
+		t.Fatalf("posts = %+v", posts)
+	}
+	p := posts[0]
+	sent := discord.Requests("POST", "/channels/"+bottest.DefaultChannelID+"/messages")
+	if len(sent) != 1 || p.MessageID == "" || p.ChannelID != bottest.DefaultChannelID {
+		t.Errorf("post = %+v", p)
+	}
+	if p.Question != "Første?" || p.Trigger != database.QuestionTriggerPoke || p.TriggeredBy == nil || *p.TriggeredBy != "admin" {
+		t.Errorf("post = %+v", p)
+	}
+}
+
+func TestHistorikkPages(t *testing.T) {
+	b, discord, db := bottest.New()
+	addApprovedQuestions(db, "Første?", "Andre?")
+	questionIDs := []int{1, 2}
+	for i := 0; i < historyPageSize+2; i++ {
+		db.AddQuestionPost(questionIDs[i%2], "msg", "", bottest.DefaultChannelID, database.QuestionTriggerMorning, "")
+	}
+	db.AddQuestionPost(2, "siste", "traad", bottest.DefaultChannelID, database.QuestionTriggerPoke, "admin")
+
+	handleHistorikk(b.Session, newMessage("!historikk", "admin-kanal"), b)
+	handleHistorikk(b.Session, newMessage("!historikk 2", "admin-kanal"), b)
+
+	embeds := discord.SentEmbeds("admin-kanal")
+	if len(embeds) != 2 {
+		t.Fatalf("embeds = %+v", embeds)
+	}
+	first := embeds[0]
+	if !strings.HasPrefix(first.Footer.Text, "Side 1 av 2 • 13 gonger stilt") {
+		t.Errorf("footer = %q", first.Footer.Text)
+	}
+	entries := strings.Split(first.Description, "\n\n")
+	if len(entries) != historyPageSize {
+		t.Fatalf("%d entries on the first page", len(entries))
+	}
+	if !strings.Contains(entries[0], "guild/"+bottest.DefaultChannelID+"/siste") || !strings.Contains(entries[0], "Andre?") || !strings.Contains(entries[0], "!poke av <@admin>") || !strings.Contains(entries[0], "<#traad>") {
+		t.Errorf("newest entry = %q", entries[0])
+	}
+	if !strings.Contains(entries[1], "Morgonposten") || strings.Contains(entries[1], "🧵") {
+		t.Errorf("entry = %q", entries[1])
+	}
+	if n := len(strings.Split(embeds[1].Description, "\n\n")); n != 3 || !strings.HasPrefix(embeds[1].Footer.Text, "Side 2 av 2") {
+		t.Errorf("second page has %d entries, footer %q", n, embeds[1].Footer.Text)
+	}
+}
+
+func TestHistorikkEmpty(t *testing.T) {
+	b, discord, _ := bottest.New()
+
+	handleHistorikk(b.Session, newMessage("!historikk", "admin-kanal"), b)
+
+	embeds := discord.SentEmbeds("admin-kanal")
+	if len(embeds) != 1 || embeds[0].Description != "Ingen daglege spørsmål er stilte enno." {
+		t.Errorf("embeds = %+v", embeds)
+	}
+}
@@ -0,0 +1,91 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+
+	"askeladden/internal/bot"
+	"askeladden/internal/bot/services"
+	"askeladden/internal/questions"
+)
+
+func init() {
+	commands["neste"] = Command{
+		name:        "neste",
+		description: "Førehandsvis dei neste daglege spørsmåla utan å stille dei (kun admin)",
+		emoji:       "🔮",
+		handler:     handleNeste,
+		adminOnly:   true,
+	}
+}
+
+const (
+	previewDefault     = 5
+	previewMax         = 20
+	previewQuestionLen = 120 // Runes shown of each question
+)
+
+func handleNeste(s *discordgo.Session, m *discordgo.MessageCreate, bot *bot.Bot) {
+	n := previewDefault
+	if args := strings.Fields(m.Content); len(args) > 1 {
+		parsed, err := strconv.Atoi(args[1])
+		if err != nil || parsed < 1 {
+			embed := services.CreateBotEmbed(s, "❓ Feil", fmt.Sprintf("Bruk: `!neste [tal]`, der tal er mellom 1 og %d.", previewMax), services.EmbedTypeError)
+			s.ChannelMessageSendEmbed(m.ChannelID, embed)
+			return
+		}
+		n = min(parsed, previewMax)
+	}
+
+	strategy := questions.FromConfig(bot.Config)
+	picks, err := questions.Preview(bot.Database, strategy, time.Now(), n)
+	if err != nil {
+		log.Printf("Failed to preview daily questions: %v", err)
+		embed := services.CreateBotEmbed(s, "❌ Feil", "Feil ved henting av spørsmål frå databasen.", services.EmbedTypeError)
+		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		return
+	}
+
+	builder := services.NewEmbedBuilder().
+		SetTitle("🔮 Dei neste daglege spørsmåla").
+		SetColorByType(services.EmbedTypeInfo).
+		SetAuthorFromBot(s).
+		SetFooter(fmt.Sprintf("Strategi: %s • ingen av spørsmåla er stilte", strategy.Name()), "")
+
+	if len(picks) == 0 {
+		builder.SetDescription("Ingen spørsmål kan stillast no.")
+	} else {
+		var lines []string
+		for i, q := range picks {
+			lines = append(lines, fmt.Sprintf("%d. %s\n    %s", i+1, previewText(q.Question), formatAsked(q.TimesAsked, q.LastAskedAt)))
+		}
+		if len(picks) < n {
+			lines = append(lines, fmt.Sprintf("\nBerre %d spørsmål kan stillast før strategien går tom.", len(picks)))
+		}
+		builder.SetDescription(strings.Join(lines, "\n"))
+	}
+	s.ChannelMessageSendEmbed(m.ChannelID, builder.Build())
+}
+
+// previewText shortens a question for the preview list
+func previewText(question string) string {
+	question = strings.Join(strings.Fields(question), " ")
+	if utf8.RuneCountInString(question) > previewQuestionLen {
+		question = string([]rune(question)[:previewQuestionLen]) + "…"
+	}
+	return question
+}
+
+// formatAsked describes how often and when a question was asked before
+func formatAsked(timesAsked int, lastAskedAt *time.Time) string {
+	if timesAsked == 0 || lastAskedAt == nil {
+		return "*aldri stilt*"
+	}
+	return fmt.Sprintf("*stilt %s, sist <t:%d:R>*", formatTimes(timesAsked), lastAskedAt.Unix())
+}
@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"askeladden/internal/bot/bottest"
+	"askeladden/internal/database/databasetest"
+	"askeladden/internal/questions"
+)
+
+// addApprovedQuestions adds approved questions in order
+func addApprovedQuestions(db *databasetest.DB, texts ...string) {
+	for _, text := range texts {
+		id, _ := db.AddQuestion(text, "forfattar", "brukar", text, "c")
+		db.ApproveQuestion(int(id), "opplysar")
+	}
+}
+
+func TestNestePreviewsWithoutAsking(t *testing.T) {
+	b, discord, db := bottest.New()
+	addApprovedQuestions(db, "Første?", "Andre?")
+
+	handleNeste(b.Session, newMessage("!neste 3", "admin-kanal"), b)
+
+	embeds := discord.SentEmbeds("admin-kanal")
+	if len(embeds) != 1 {
+		t.Fatalf("embeds = %+v", embeds)
+	}
+	lines := strings.Split(embeds[0].Description, "\n")
+	if len(lines) != 6 || lines[0] != "1. Første?" || lines[2] != "2. Andre?" || lines[4] != "3. Første?" {
+		t.Errorf("preview = %q", embeds[0].Description)
+	}
+	if !strings.Contains(embeds[0].Footer.Text, questions.StrategyLeastAsked) {
+		t.Errorf("footer = %q", embeds[0].Footer.Text)
+	}
+
+	approved, _ := db.GetApprovedQuestions()
+	for _, q := range approved {
+		if q.TimesAsked != 0 {
+			t.Errorf("preview asked %q", q.Question)
+		}
+	}
+	if sent := discord.SentMessages(bottest.DefaultChannelID); len(sent) != 0 {
+		t.Errorf("preview posted %d daily questions", len(sent))
+	}
+}
+
+func TestNesteMatchesPoke(t *testing.T) {
+	b, discord, db := bottest.New()
+	b.Config.Scheduler.Strategy = questions.StrategyExhaust
+	addApprovedQuestions(db, "A?", "B?", "C?", "D?")
+
+	handleNeste(b.Session, newMessage("!neste 1", "admin-kanal"), b)
+	handlePoke(b.Session, newMessage("!poke", "admin-kanal"), b)
+
+	preview := discord.SentEmbeds("admin-kanal")[0].Description
+	sent := discord.SentMessages(bottest.DefaultChannelID)
+	if len(sent) != 1 || !strings.HasPrefix(preview, "1. "+sent[0].Embeds[0].Description) {
+		t.Errorf("preview %q, but poke asked %+v", preview, sent)
+	}
+}
+
+func TestNesteCooldownRunsOut(t *testing.T) {
+	b, discord, db := bottest.New()
+	b.Config.Scheduler.Strategy = questions.StrategyCooldown
+	addApprovedQuestions(db, "A?", "B?")
+
+	handleNeste(b.Session, newMessage("!neste 5", "admin-kanal"), b)
+
+	embeds := discord.SentEmbeds("admin-kanal")
+	if len(embeds) != 1 || !strings.Contains(embeds[0].Description, "Berre 2 spørsmål") {
+		t.Errorf("embeds = %+v", embeds)
+	}
+}
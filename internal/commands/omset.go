@@ -0,0 +1,130 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+
+	"askeladden/internal/bannedwords"
+	"askeladden/internal/bot"
+	"askeladden/internal/bot/services"
+)
+
+func init() {
+	commands["omset"] = Command{
+		name:        "omset",
+		description: "Skriv om ein tekst med dei nynorske forslaga for kvart forbode ord (eller svar på ei melding)",
+		emoji:       "🔁",
+		handler:     Omset,
+	}
+}
+
+const (
+	rewriteMaxText  = 3500 // Keeps the rewritten text and both fields within the 6000 character embed limit
+	rewriteMaxLines = 15   // Forms listed in each field
+	rewriteMaxField = 1024 // Discord's limit for an embed field value
+)
+
+// Omset handsamar omset-kommandoen
+func Omset(s *discordgo.Session, m *discordgo.MessageCreate, bot *bot.Bot) {
+	text := strings.TrimSpace(strings.TrimPrefix(m.Content, strings.Fields(m.Content)[0]))
+	if text == "" && m.ReferencedMessage != nil {
+		text = m.ReferencedMessage.Content
+	}
+	if text == "" {
+		embed := services.CreateBotEmbed(s, "❓ Feil", "Bruk: `!omset <tekst>`, eller svar på ei melding med `!omset`.\n\nDøme: `!omset Jeg vet ikke hvordan`", services.EmbedTypeError)
+		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		return
+	}
+
+	s.ChannelMessageSendEmbed(m.ChannelID, RewriteEmbed(s, bot, text))
+}
+
+// RewriteEmbed skriv om teksten med forslaga til dei forbodne orda. Former
+// boten ikkje kan byte ut åleine, vert understreka og lista opp.
+func RewriteEmbed(s *discordgo.Session, bot *bot.Bot, text string) *discordgo.MessageEmbed {
+	rewritten, replacements := bot.BannedWords.Rewrite(text, func(r bannedwords.Replacement) string {
+		return "__" + r.Original + "__"
+	})
+
+	builder := services.NewEmbedBuilder().
+		SetTitle("🔁 Omsett til nynorsk").
+		SetColorByType(services.EmbedTypeInfo).
+		SetAuthorFromBot(s)
+
+	if len(replacements) == 0 {
+		return builder.SetDescription("Fann ingen forbodne ord i teksten. 🎉").Build()
+	}
+	if utf8.RuneCountInString(rewritten) > rewriteMaxText {
+		rewritten = string([]rune(rewritten)[:rewriteMaxText]) + "…"
+	}
+	builder.SetDescription(rewritten)
+
+	var replaced, ambiguous []string
+	seen := make(map[string]bool)
+	for _, r := range replacements {
+		form := strings.ToLower(r.Original)
+		if seen[form] {
+			continue
+		}
+		seen[form] = true
+
+		if r.Ambiguous() {
+			ambiguous = append(ambiguous, formatAmbiguous(r))
+		} else {
+			replaced = append(replaced, fmt.Sprintf("%s → **%s**", form, strings.ToLower(r.Replacement)))
+		}
+	}
+	if len(replaced) > 0 {
+		builder.AddField("✏️ Bytt ut", joinLimited(replaced), false)
+	}
+	if len(ambiguous) > 0 {
+		builder.AddField("❓ Sjå over sjølv", joinLimited(ambiguous), false)
+		builder.SetFooter("Dei understreka orda har fleire eller ingen forslag, eller står i ei anna form enn forslaget", "")
+	}
+	return builder.Build()
+}
+
+// formatAmbiguous explains why a form was not replaced
+func formatAmbiguous(r bannedwords.Replacement) string {
+	form := strings.ToLower(r.Original)
+	line := "**" + form + "**"
+	if !strings.EqualFold(form, r.Word.Word) {
+		line += fmt.Sprintf(" (høyrer til «%s»)", r.Word.Word)
+	}
+	if len(r.Word.Suggestions) == 0 {
+		return line + ": ingen forslag"
+	}
+	return line + ": " + strings.Join(r.Word.Suggestions, " / ")
+}
+
+// joinLimited joins lines for an embed field, leaving out lines past the line
+// limit or that would make the field longer than Discord allows
+func joinLimited(lines []string) string {
+	// Room for the "… og N til" line
+	limit := rewriteMaxField - 20
+
+	var kept []string
+	length := 0
+	for _, line := range lines {
+		if len(kept) == rewriteMaxLines {
+			break
+		}
+		n := utf8.RuneCountInString(line) + 1
+		if length+n > limit {
+			if len(kept) == 0 {
+				// A single line with a very long suggestion list
+				kept = append(kept, string([]rune(line)[:limit-2])+"…")
+			}
+			break
+		}
+		kept = append(kept, line)
+		length += n
+	}
+	if rest := len(lines) - len(kept); rest > 0 {
+		kept = append(kept, fmt.Sprintf("… og %d til", rest))
+	}
+	return strings.Join(kept, "\n")
+}
@@ -0,0 +1,91 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+
+	"askeladden/internal/bot/bottest"
+)
+
+func TestOmsetRewritesText(t *testing.T) {
+	b, discord, db := bottest.New()
+	addApprovedWords(db, "ikke", "hvordan", "noen")
+	db.UpdateBannedWordSuggestions(1, []string{"ikkje"})
+	db.UpdateBannedWordSuggestions(2, []string{"korleis", "kor"})
+
+	Omset(b.Session, newMessage("!omset Ikke spør meg hvordan noen gjer det, ikke", "kanal"), b)
+
+	embeds := discord.SentEmbeds("kanal")
+	if len(embeds) != 1 {
+		t.Fatalf("embeds = %+v", embeds)
+	}
+	embed := embeds[0]
+	if want := "Ikkje spør meg __hvordan__ __noen__ gjer det, ikkje"; embed.Description != want {
+		t.Errorf("description = %q, want %q", embed.Description, want)
+	}
+	if len(embed.Fields) != 2 || embed.Fields[0].Value != "ikke → **ikkje**" {
+		t.Fatalf("fields = %+v", embed.Fields)
+	}
+	if want := "**hvordan**: korleis / kor\n**noen**: ingen forslag"; embed.Fields[1].Value != want {
+		t.Errorf("ambiguous = %q, want %q", embed.Fields[1].Value, want)
+	}
+}
+
+func TestOmsetReply(t *testing.T) {
+	b, discord, db := bottest.New()
+	addApprovedWords(db, "ikke")
+	db.UpdateBannedWordSuggestions(1, []string{"ikkje"})
+
+	m := newMessage("!omset", "kanal")
+	m.ReferencedMessage = &discordgo.Message{Content: "Det er ikke lett"}
+	Omset(b.Session, m, b)
+
+	if embeds := discord.SentEmbeds("kanal"); len(embeds) != 1 || embeds[0].Description != "Det er ikkje lett" {
+		t.Errorf("embeds = %+v", embeds)
+	}
+}
+
+func TestOmsetWithoutBannedWords(t *testing.T) {
+	b, discord, db := bottest.New()
+	addApprovedWords(db, "ikke")
+
+	Omset(b.Session, newMessage("!omset Det er ikkje lett", "kanal"), b)
+
+	if embeds := discord.SentEmbeds("kanal"); len(embeds) != 1 || !strings.Contains(embeds[0].Description, "ingen forbodne ord") {
+		t.Errorf("embeds = %+v", embeds)
+	}
+}
+
+func TestJoinLimitedKeepsFieldsWithinDiscordLimit(t *testing.T) {
+	long := "**ikke**: " + strings.Repeat("ikkje / ", 30)
+	cases := []struct {
+		lines []string
+		kept  int
+	}{
+		{lines: repeatLine("ikke → **ikkje**", 20), kept: rewriteMaxLines},
+		{lines: repeatLine(long, 10), kept: 4},
+		{lines: []string{strings.Repeat("x", 3000), "ikke"}, kept: 1},
+	}
+	for _, c := range cases {
+		field := joinLimited(c.lines)
+		if n := utf8.RuneCountInString(field); n > rewriteMaxField {
+			t.Errorf("field is %d characters long", n)
+		}
+		lines := strings.Split(field, "\n")
+		if len(lines) != c.kept+1 || !strings.HasPrefix(lines[c.kept], "… og ") {
+			t.Errorf("kept %d lines, want %d and a count of the rest", len(lines)-1, c.kept)
+		}
+	}
+}
+
+// repeatLine returns n copies of line
+func repeatLine(line string, n int) []string {
+	lines := make([]string, n)
+	for i := range lines {
+		lines[i] = line
+	}
+	return lines
+}
@@ -0,0 +1,73 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+
+	"askeladden/internal/bot"
+	"askeladden/internal/bot/services"
+)
+
+func init() {
+	commands["opphev"] = Command{
+		name:        "opphev",
+		description: "Foreslå å oppheve forbodet mot eit ord",
+		emoji:       "🔓",
+		handler:     Opphev,
+	}
+}
+
+const opphevUsage = "Bruk: `!opphev <ord> <grunngiving>`\n\n" +
+	"Framlegget vert lagt ut i retting-kanalen og må godkjennast av både ein opplysar og ein rettskrivar, akkurat som eit forbod."
+
+// Opphev handsamar opphev-kommandoen
+func Opphev(s *discordgo.Session, m *discordgo.MessageCreate, bot *bot.Bot) {
+	parts := strings.Fields(m.Content)
+	if len(parts) < 2 {
+		embed := services.CreateBotEmbed(s, "❓ Feil", opphevUsage, services.EmbedTypeError)
+		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		return
+	}
+
+	bannedWord := findReportedWord(s, m, bot, parts[1])
+	if bannedWord == nil {
+		return
+	}
+
+	if !bannedWord.IsActive() {
+		embed := services.CreateBotEmbed(s, "❓ Ikkje forbode", fmt.Sprintf("«%s» er ikkje eit godkjent forbode ord, så det er ikkje noko forbod å oppheve.", bannedWord.Word), services.EmbedTypeError)
+		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		return
+	}
+
+	pending, err := bot.Database.GetPendingUnbanProposalForWord(bannedWord.ID)
+	if err != nil {
+		log.Printf("Failed to check pending unban proposals for banned word %d: %v", bannedWord.ID, err)
+		embed := services.CreateBotEmbed(s, "❌ Feil", "Kunne ikkje sjekke om det alt finst eit framlegg.", services.EmbedTypeError)
+		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		return
+	}
+	if pending != nil {
+		embed := services.CreateBotEmbed(s, "⏳ Alt foreslått", fmt.Sprintf("Det finst alt eit framlegg om å oppheve forbodet mot «%s» som ventar på godkjenning.", bannedWord.Word), services.EmbedTypeWarning)
+		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		return
+	}
+
+	reason := strings.Join(parts[2:], " ")
+	proposalID, err := bot.Database.AddUnbanProposal(bannedWord.ID, m.Author.ID, m.Author.Username, reason)
+	if err != nil {
+		embed := services.CreateBotEmbed(s, "❌ Feil", "Kunne ikkje lagre framlegget.", services.EmbedTypeError)
+		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		return
+	}
+
+	approvalService := &services.ApprovalService{Bot: bot}
+	approvalService.PostUnbanProposalToRettingChannel(proposalID)
+	approvalService.SyncForumThread(s, bannedWord)
+
+	embed := services.CreateBotEmbed(s, "🔓 Framlegg sendt", fmt.Sprintf("Framlegget om å oppheve forbodet mot «%s» er sendt til godkjenning.", bannedWord.Word), services.EmbedTypeSuccess)
+	s.ChannelMessageSendEmbed(m.ChannelID, embed)
+}
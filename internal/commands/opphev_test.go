@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+
+	"askeladden/internal/bot/bottest"
+)
+
+func TestOpphevPostsProposal(t *testing.T) {
+	b, discord, db := bottest.New()
+	id, _ := db.AddBannedWordPending("ikke", "", "u1", "brukar", "traad", "")
+	db.ApproveBannedWordCombined(int(id), []string{"opp"}, []string{"rett"})
+	discord.Respond("GET", "/channels/traad", &discordgo.Channel{ID: "traad", AppliedTags: []string{"tag-godkjent"}})
+
+	Opphev(b.Session, newMessage("!opphev ikke Det er eit namn på ein stad", "kanal"), b)
+
+	proposal, _ := db.GetPendingUnbanProposalForWord(int(id))
+	if proposal == nil || proposal.ProposerID != "admin" || proposal.Reason != "Det er eit namn på ein stad" {
+		t.Fatalf("proposal = %+v", proposal)
+	}
+	if proposal.ApprovalMessageID == nil {
+		t.Fatal("approval message ID was not stored")
+	}
+	embeds := discord.SentEmbeds(bottest.RettingChannelID)
+	if len(embeds) != 1 || embeds[0].Title != "🔓 Opphev: ikke" {
+		t.Fatalf("retting embeds = %+v", embeds)
+	}
+
+	// The forum thread shows that a decision is pending again
+	edits := discord.ChannelEdits("traad")
+	if len(edits) != 1 || strings.Join(*edits[0].AppliedTags, ",") != "tag-godkjent,tag-ventar" {
+		t.Errorf("thread edits = %+v, want godkjent and ventar", edits)
+	}
+
+	// A second proposal while the first is pending is refused
+	Opphev(b.Session, newMessage("!opphev ikke", "kanal"), b)
+	if embeds := discord.SentEmbeds(bottest.RettingChannelID); len(embeds) != 1 {
+		t.Errorf("duplicate proposal posted: %d embeds", len(embeds))
+	}
+}
+
+func TestOpphevRequiresActiveBan(t *testing.T) {
+	b, discord, db := bottest.New()
+	id, _ := db.AddBannedWordPending("ikke", "", "u1", "brukar", "", "")
+
+	Opphev(b.Session, newMessage("!opphev ikke", "kanal"), b)
+
+	if proposal, _ := db.GetPendingUnbanProposalForWord(int(id)); proposal != nil {
+		t.Errorf("proposal created for a pending word: %+v", proposal)
+	}
+	if embeds := discord.SentEmbeds(bottest.RettingChannelID); len(embeds) != 0 {
+		t.Errorf("retting embeds = %+v", embeds)
+	}
+}
@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+
+	"askeladden/internal/bannedwords"
+	"askeladden/internal/bot"
+	"askeladden/internal/bot/services"
+)
+
+func init() {
+	commands["ordbok"] = Command{
+		name:        "ordbok",
+		description: "Slå opp om eitt eller fleire ord finst på nynorsk eller berre på bokmål",
+		emoji:       "📚",
+		handler:     Ordbok,
+	}
+}
+
+// ordbokMaxWords limits how many words are looked up at once
+const ordbokMaxWords = 10
+
+// Ordbok handsamar ordbok-kommandoen
+func Ordbok(s *discordgo.Session, m *discordgo.MessageCreate, bot *bot.Bot) {
+	if bot.Dictionary == nil {
+		embed := services.CreateBotEmbed(s, "❌ Inga ordbok", "Ordlistene er ikkje sette opp på denne boten.", services.EmbedTypeError)
+		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		return
+	}
+
+	tokens := bannedwords.Tokenize(strings.TrimPrefix(m.Content, strings.Fields(m.Content)[0]))
+	if len(tokens) == 0 {
+		embed := services.CreateBotEmbed(s, "❓ Feil", "Bruk: `!ordbok <ord> [ord ...]`\n\nDøme: `!ordbok ikke ikkje`", services.EmbedTypeError)
+		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		return
+	}
+
+	var lines []string
+	seen := make(map[string]bool)
+	for _, token := range tokens {
+		if seen[token.Text] {
+			continue
+		}
+		seen[token.Text] = true
+		if len(lines) == ordbokMaxWords {
+			lines = append(lines, fmt.Sprintf("… berre dei %d første orda er slått opp.", ordbokMaxWords))
+			break
+		}
+
+		line := fmt.Sprintf("**%s** – %s", token.Text, services.DictionaryLabel(bot.Dictionary.Lookup(token.Text)))
+		if _, banned := bot.BannedWords.Lookup(token.Text); banned {
+			line += " • 🚫 forbode ord"
+		}
+		lines = append(lines, line)
+	}
+
+	nynorsk, bokmal := bot.Dictionary.Size()
+	embed := services.NewEmbedBuilder().
+		SetTitle("📚 Ordbok").
+		SetDescription(strings.Join(lines, "\n")).
+		SetColorByType(services.EmbedTypeInfo).
+		SetAuthorFromBot(s).
+		SetFooter(fmt.Sprintf("%d nynorske og %d bokmålske ordformer", nynorsk, bokmal), "").
+		Build()
+	s.ChannelMessageSendEmbed(m.ChannelID, embed)
+}
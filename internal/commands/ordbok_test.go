@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"askeladden/internal/bot/bottest"
+	"askeladden/internal/dictionary"
+)
+
+func TestOrdbokLooksUpWords(t *testing.T) {
+	b, discord, db := bottest.New()
+	b.Dictionary = dictionary.New([]string{"ikkje"}, []string{"ikke"})
+	addApprovedWords(db, "ikke")
+
+	Ordbok(b.Session, newMessage("!ordbok Ikke, ikkje ikke xyz", "kanal"), b)
+
+	embeds := discord.SentEmbeds("kanal")
+	if len(embeds) != 1 {
+		t.Fatalf("embeds = %+v", embeds)
+	}
+	want := "**ikke** – 📕 Berre bokmål • 🚫 forbode ord\n" +
+		"**ikkje** – ✅ Finst i nynorsk ordliste\n" +
+		"**xyz** – ❓ Ukjend"
+	if embeds[0].Description != want {
+		t.Errorf("description = %q, want %q", embeds[0].Description, want)
+	}
+	if embeds[0].Footer == nil || !strings.Contains(embeds[0].Footer.Text, "1 nynorske og 1 bokmålske") {
+		t.Errorf("footer = %+v", embeds[0].Footer)
+	}
+}
+
+func TestOrdbokWithoutDictionary(t *testing.T) {
+	b, discord, _ := bottest.New()
+
+	Ordbok(b.Session, newMessage("!ordbok ikke", "kanal"), b)
+
+	if embeds := discord.SentEmbeds("kanal"); len(embeds) != 1 || !strings.Contains(embeds[0].Title, "Inga ordbok") {
+		t.Errorf("embeds = %+v", embeds)
+	}
+}
@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+
+	"askeladden/internal/bannedwords"
+	"askeladden/internal/bot"
+	"askeladden/internal/bot/services"
+)
+
+func init() {
+	commands["ordeksport"] = Command{
+		name:        "ordeksport",
+		description: "Eksporter dei godkjende forbodne orda som CSV eller YAML (`!ordeksport yaml`)",
+		emoji:       "📤",
+		handler:     Ordeksport,
+		adminOnly:   true,
+	}
+}
+
+// Ordeksport handsamar ordeksport-kommandoen
+func Ordeksport(s *discordgo.Session, m *discordgo.MessageCreate, bot *bot.Bot) {
+	format := bannedwords.FormatCSV
+	if parts := strings.Fields(m.Content); len(parts) > 1 {
+		format = strings.ToLower(parts[1])
+		if format == "yml" {
+			format = bannedwords.FormatYAML
+		}
+	}
+	if format != bannedwords.FormatCSV && format != bannedwords.FormatYAML {
+		embed := services.CreateBotEmbed(s, "❓ Feil", "Bruk: `!ordeksport` for CSV eller `!ordeksport yaml` for YAML.", services.EmbedTypeError)
+		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		return
+	}
+
+	entries, err := bannedwords.ExportList(bot.Database)
+	if err != nil {
+		log.Printf("Failed to export banned words: %v", err)
+		embed := services.CreateBotEmbed(s, "❌ Feil", "Kunne ikkje hente dei forbodne orda.", services.EmbedTypeError)
+		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := bannedwords.WriteList(&buf, format, entries); err != nil {
+		log.Printf("Failed to write word list: %v", err)
+		return
+	}
+
+	_, err = s.ChannelMessageSendComplex(m.ChannelID, &discordgo.MessageSend{
+		Content: fmt.Sprintf("📤 %d godkjende forbodne ord", len(entries)),
+		Files: []*discordgo.File{{
+			Name:        "forbodne-ord." + format,
+			ContentType: "text/plain; charset=utf-8",
+			Reader:      &buf,
+		}},
+	})
+	if err != nil {
+		log.Printf("Failed to send word list: %v", err)
+	}
+}
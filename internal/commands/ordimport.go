@@ -0,0 +1,128 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+
+	"askeladden/internal/bannedwords"
+	"askeladden/internal/bot"
+	"askeladden/internal/bot/services"
+)
+
+// maxWordListSize limits how large an attached word list may be
+const maxWordListSize = 1 << 20
+
+const wordListTooLarge = "Ordlista kan vere på høgst 1 MiB. Del ho opp og importer kvar del for seg."
+
+func init() {
+	commands["ordimport"] = Command{
+		name:        "ordimport",
+		description: "Importer ei ordliste (CSV/YAML-vedlegg) som godkjende forbodne ord. Legg til «prøv» for å sjå endringane først.",
+		emoji:       "📥",
+		handler:     Ordimport,
+		adminOnly:   true,
+	}
+}
+
+// Ordimport handsamar ordimport-kommandoen
+func Ordimport(s *discordgo.Session, m *discordgo.MessageCreate, bot *bot.Bot) {
+	parts := strings.Fields(m.Content)
+	dryRun := len(parts) > 1 && (parts[1] == "prøv" || parts[1] == "test")
+
+	if len(m.Attachments) == 0 {
+		embed := services.CreateBotEmbed(s, "❓ Feil", "Legg ved ei ordliste som .csv (kolonnane word, reason, suggestions) eller .yaml.\n\nBruk: `!ordimport` for å importere, eller `!ordimport prøv` for å sjå endringane utan å lagre.", services.EmbedTypeError)
+		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		return
+	}
+	attachment := m.Attachments[0]
+
+	format, err := bannedwords.FormatFromFilename(attachment.Filename)
+	if err != nil {
+		embed := services.CreateBotEmbed(s, "❓ Feil", err.Error(), services.EmbedTypeError)
+		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		return
+	}
+
+	if attachment.Size > maxWordListSize {
+		embed := services.CreateBotEmbed(s, "❌ For stor ordliste", wordListTooLarge, services.EmbedTypeError)
+		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		return
+	}
+
+	data, err := downloadWordList(s, attachment.URL)
+	if err != nil {
+		log.Printf("Failed to download word list %s: %v", attachment.URL, err)
+		embed := services.CreateBotEmbed(s, "❌ Feil", "Kunne ikkje laste ned vedlegget.", services.EmbedTypeError)
+		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		return
+	}
+	// Never parse a cut-off list: the last entry could be imported shortened
+	if len(data) > maxWordListSize {
+		embed := services.CreateBotEmbed(s, "❌ For stor ordliste", wordListTooLarge, services.EmbedTypeError)
+		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		return
+	}
+
+	entries, err := bannedwords.ReadList(bytes.NewReader(data), format)
+	if err != nil {
+		embed := services.CreateBotEmbed(s, "❌ Ugyldig ordliste", err.Error(), services.EmbedTypeError)
+		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		return
+	}
+
+	diff, err := bannedwords.ImportList(bot.Database, entries, m.Author.ID, m.Author.Username, dryRun)
+	if !dryRun {
+		// Also after an error, as the words before it are already stored
+		bot.BannedWords.Invalidate()
+	}
+	if err != nil {
+		log.Printf("Failed to import word list: %v", err)
+		embed := services.CreateBotEmbed(s, "❌ Feil", fmt.Sprintf("Importen stoppa: %v", err), services.EmbedTypeError)
+		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		return
+	}
+
+	title := "📥 Ordliste importert"
+	if dryRun {
+		title = "📥 Ordimport (prøvekøyring, ingenting lagra)"
+	}
+	description := fmt.Sprintf("**%d** nye, **%d** endra, **%d** uendra.", len(diff.Added), len(diff.Updated), len(diff.Unchanged))
+	if diff.HasChanges() {
+		description += "\n```diff\n" + truncateLines(diff.String(), 3800) + "```"
+	}
+	embed := services.CreateBotEmbed(s, title, description, services.EmbedTypeSuccess)
+	s.ChannelMessageSendEmbed(m.ChannelID, embed)
+}
+
+// downloadWordList fetches an attachment, reading at most one byte more than
+// maxWordListSize so the caller can tell if the list is too large
+func downloadWordList(s *discordgo.Session, url string) ([]byte, error) {
+	resp, err := s.Client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxWordListSize+1))
+}
+
+// truncateLines cuts text at a line break so it fits within limit bytes
+func truncateLines(text string, limit int) string {
+	if len(text) <= limit {
+		return text
+	}
+	cut := strings.LastIndex(text[:limit], "\n")
+	if cut < 0 {
+		cut = limit
+	}
+	remaining := strings.Count(text[cut:], "\n")
+	return text[:cut+1] + fmt.Sprintf("… og %d til\n", remaining)
+}
@@ -0,0 +1,98 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+
+	"askeladden/internal/bot/bottest"
+)
+
+// withAttachment adds a word list attachment served by the fake API
+func withAttachment(m *discordgo.MessageCreate, discord *bottest.Discord, name, content string) *discordgo.MessageCreate {
+	discord.Respond("GET", "/attachments/"+name, []byte(content))
+	m.Attachments = []*discordgo.MessageAttachment{{Filename: name, URL: "https://cdn.discordapp.com/attachments/" + name}}
+	return m
+}
+
+func TestOrdimportDryRunThenImport(t *testing.T) {
+	b, discord, db := bottest.New()
+	list := "word,reason,suggestions\nikke,Bokmål,ikkje\n"
+
+	Ordimport(b.Session, withAttachment(newMessage("!ordimport prøv", "kanal"), discord, "ord.csv", list), b)
+	if isBanned, _, _ := db.IsBannedWord("ikke"); isBanned {
+		t.Fatal("dry run stored the word")
+	}
+	embeds := discord.SentEmbeds("kanal")
+	if len(embeds) != 1 || !strings.Contains(embeds[0].Title, "prøvekøyring") || !strings.Contains(embeds[0].Description, "+ ikke → ikkje") {
+		t.Fatalf("dry run embeds = %+v", embeds)
+	}
+
+	Ordimport(b.Session, withAttachment(newMessage("!ordimport", "kanal"), discord, "ord.csv", list), b)
+	_, bw, _ := db.IsBannedWord("ikke")
+	if bw == nil || bw.ApprovalStatus != "fully_approved" || *bw.OpplysarApprovedBy != "admin" {
+		t.Fatalf("imported word = %+v", bw)
+	}
+	if found := b.BannedWords.FindAll("ikke"); len(found) != 1 {
+		t.Error("matcher was not invalidated after import")
+	}
+}
+
+func TestOrdimportRejectsUnknownFormat(t *testing.T) {
+	b, discord, _ := bottest.New()
+
+	Ordimport(b.Session, withAttachment(newMessage("!ordimport", "kanal"), discord, "ord.txt", "ikke"), b)
+
+	embeds := discord.SentEmbeds("kanal")
+	if len(embeds) != 1 || !strings.Contains(embeds[0].Description, "ukjent filformat") {
+		t.Fatalf("embeds = %+v", embeds)
+	}
+}
+
+func TestOrdeksportSendsFile(t *testing.T) {
+	b, discord, db := bottest.New()
+	db.ImportBannedWord("ikke", "Bokmål", []string{"ikkje"}, "imp", "imp")
+
+	Ordeksport(b.Session, newMessage("!ordeksport yaml", "kanal"), b)
+
+	posts := discord.Requests("POST", "/channels/kanal/messages")
+	if len(posts) != 1 {
+		t.Fatalf("expected one message, got %d", len(posts))
+	}
+	body := string(posts[0].Body)
+	if !strings.Contains(body, `filename="forbodne-ord.yaml"`) || !strings.Contains(body, "- word: ikke") {
+		t.Errorf("export message body:\n%s", body)
+	}
+}
+
+func TestOrdimportRejectsTooLargeList(t *testing.T) {
+	b, discord, db := bottest.New()
+	list := "word,reason,suggestions\nikke,Bokmål,ikkje\n" + strings.Repeat("x", maxWordListSize)
+
+	Ordimport(b.Session, withAttachment(newMessage("!ordimport", "kanal"), discord, "ord.csv", list), b)
+
+	if isBanned, _, _ := db.IsBannedWord("ikke"); isBanned {
+		t.Error("a cut-off list was imported")
+	}
+	embeds := discord.SentEmbeds("kanal")
+	if len(embeds) != 1 || embeds[0].Title != "❌ For stor ordliste" {
+		t.Fatalf("embeds = %+v", embeds)
+	}
+}
+
+func TestOrdimportRejectsFailedDownload(t *testing.T) {
+	b, discord, db := bottest.New()
+	m := newMessage("!ordimport", "kanal")
+	m.Attachments = []*discordgo.MessageAttachment{{Filename: "ord.csv", URL: "https://cdn.discordapp.com/attachments/borte.csv"}}
+
+	Ordimport(b.Session, m, b)
+
+	if words, _ := db.GetBannedWords(); len(words) != 0 {
+		t.Errorf("error page was imported: %+v", words)
+	}
+	embeds := discord.SentEmbeds("kanal")
+	if len(embeds) != 1 || embeds[0].Description != "Kunne ikkje laste ned vedlegget." {
+		t.Fatalf("embeds = %+v", embeds)
+	}
+}
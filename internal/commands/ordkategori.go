@@ -0,0 +1,150 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+
+	"askeladden/internal/bot"
+	"askeladden/internal/bot/services"
+	"askeladden/internal/database"
+	"askeladden/internal/permissions"
+)
+
+func init() {
+	commands["ordkategori"] = Command{
+		name:        "ordkategori",
+		description: "Vis eller set kategori og alvor for eit forbode ord (opplysarar og rettskrivarar)",
+		emoji:       "🏷️",
+		handler:     Ordkategori,
+		aliases:     []string{"kategori"},
+	}
+}
+
+const ordkategoriUsage = "Bruk: `!ordkategori <ord>` for å sjå kategorien, eller `!ordkategori <ord> <kategori> [alvor]` for å setje han.\n\n" +
+	"Kategoriar: `bokmål`, `anglisisme`, `særskriving`, `sidemål`\n" +
+	"Alvor: `låg` (eit vennleg tips), `middels` (ein grammatisk merknad) eller `høg` (ei åtvaring). Utan alvor får sidemålsformer låg og alle andre middels.\n\n" +
+	"Døme: `!ordkategori weekend anglisisme` eller `!ordkategori ikke bokmål høg`"
+
+// categoryArgs maps command arguments to categories
+var categoryArgs = map[string]string{
+	"bokmål":       database.CategoryBokmal,
+	"bokmal":       database.CategoryBokmal,
+	"bokmålsform":  database.CategoryBokmal,
+	"anglisisme":   database.CategoryAnglicism,
+	"engelsk":      database.CategoryAnglicism,
+	"særskriving":  database.CategoryCompound,
+	"saerskriving": database.CategoryCompound,
+	"sidemål":      database.CategorySidemal,
+	"sidemal":      database.CategorySidemal,
+	"sidemålsform": database.CategorySidemal,
+}
+
+// severityArgs maps command arguments to severities
+var severityArgs = map[string]string{
+	"låg":     database.SeverityLow,
+	"lav":     database.SeverityLow,
+	"low":     database.SeverityLow,
+	"middels": database.SeverityMedium,
+	"medium":  database.SeverityMedium,
+	"høg":     database.SeverityHigh,
+	"høy":     database.SeverityHigh,
+	"high":    database.SeverityHigh,
+}
+
+// Ordkategori handsamar ordkategori-kommandoen
+func Ordkategori(s *discordgo.Session, m *discordgo.MessageCreate, bot *bot.Bot) {
+	parts := strings.Fields(m.Content)
+	if len(parts) < 2 {
+		embed := services.CreateBotEmbed(s, "❓ Feil", ordkategoriUsage, services.EmbedTypeError)
+		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		return
+	}
+
+	bannedWord := findReportedWord(s, m, bot, parts[1])
+	if bannedWord == nil {
+		return
+	}
+
+	// Only the word: anyone may see the category
+	if len(parts) == 2 {
+		embed := services.CreateBotEmbed(s, "🏷️ Kategori for «"+bannedWord.Word+"»", formatCategory(bannedWord.Category, bannedWord.Severity), services.EmbedTypeInfo)
+		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		return
+	}
+
+	permManager := permissions.NewPermissionManager(bot.Config)
+	if permManager.GetUserRole(s, m.GuildID, m.Author.ID) == permissions.RoleNone {
+		embed := services.CreateBotEmbed(s, "⛔ Inga tilgang", "Berre opplysarar og rettskrivarar kan endre kategoriar.", services.EmbedTypeError)
+		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		return
+	}
+
+	category, ok := categoryArgs[strings.ToLower(parts[2])]
+	if !ok {
+		embed := services.CreateBotEmbed(s, "❓ Ukjent kategori", ordkategoriUsage, services.EmbedTypeError)
+		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		return
+	}
+	severity := database.DefaultSeverity(category)
+	if len(parts) > 3 {
+		if severity, ok = severityArgs[strings.ToLower(parts[3])]; !ok {
+			embed := services.CreateBotEmbed(s, "❓ Ukjent alvor", ordkategoriUsage, services.EmbedTypeError)
+			s.ChannelMessageSendEmbed(m.ChannelID, embed)
+			return
+		}
+	}
+
+	if err := bot.Database.UpdateBannedWordCategory(bannedWord.ID, category, severity); err != nil {
+		log.Printf("Failed to update category for banned word %d: %v", bannedWord.ID, err)
+		embed := services.CreateBotEmbed(s, "❌ Feil", "Kunne ikkje lagre kategorien.", services.EmbedTypeError)
+		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		return
+	}
+	// The warnings read the category from the cached words
+	bot.BannedWords.Invalidate()
+
+	bannedWord.Category = category
+	bannedWord.Severity = severity
+	updateApprovalCategory(s, bot, bannedWord)
+
+	embed := services.CreateBotEmbed(s, "✅ Kategori oppdatert for «"+bannedWord.Word+"»", formatCategory(category, severity), services.EmbedTypeSuccess)
+	s.ChannelMessageSendEmbed(m.ChannelID, embed)
+}
+
+// updateApprovalCategory shows the new category on the word's message in the
+// retting channel, so approvers see what they approve
+func updateApprovalCategory(s *discordgo.Session, bot *bot.Bot, bannedWord *database.BannedWord) {
+	if bannedWord.ApprovalMessageID == nil || *bannedWord.ApprovalMessageID == "" {
+		return
+	}
+	channelID := bot.Config.BannedWords.ApprovalChannelID
+	message, err := s.ChannelMessage(channelID, *bannedWord.ApprovalMessageID)
+	if err != nil || len(message.Embeds) == 0 {
+		log.Printf("Failed to get approval message for banned word %d: %v", bannedWord.ID, err)
+		return
+	}
+
+	embed := message.Embeds[0]
+	field := services.BannedWordCategoryField(bannedWord)
+	replaced := false
+	for i, f := range embed.Fields {
+		if f.Name == services.CategoryFieldName {
+			embed.Fields[i] = field
+			replaced = true
+		}
+	}
+	if !replaced {
+		embed.Fields = append(embed.Fields, field)
+	}
+	if _, err := s.ChannelMessageEditEmbed(channelID, message.ID, embed); err != nil {
+		log.Printf("Failed to update approval message for banned word %d: %v", bannedWord.ID, err)
+	}
+}
+
+// formatCategory describes a category and severity for an embed description
+func formatCategory(category, severity string) string {
+	return fmt.Sprintf("**%s** med %s alvor", services.CategoryLabel(category), services.SeverityLabel(severity))
+}
@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+
+	"askeladden/internal/bot/bottest"
+	"askeladden/internal/database"
+)
+
+func TestOrdkategoriSetsCategory(t *testing.T) {
+	b, discord, db := bottest.New()
+	id, _ := db.AddBannedWordPending("nogen", "", "u1", "brukar", "", "")
+	db.UpdateBannedWordApprovalMessageID(int(id), "godkjenning")
+	discord.SetMember("admin", bottest.OpplysarRoleID)
+	discord.Respond("GET", "/channels/"+bottest.RettingChannelID+"/messages/godkjenning", &discordgo.Message{
+		ID:     "godkjenning",
+		Embeds: []*discordgo.MessageEmbed{{Title: "nogen", Fields: []*discordgo.MessageEmbedField{{Name: "🏷️ Kategori", Value: "Bokmålsform • middels alvor"}}}},
+	})
+
+	Ordkategori(b.Session, newMessage("!ordkategori nogen sidemål", "kanal"), b)
+
+	bw, _ := db.GetBannedWordByID(int(id))
+	if bw.Category != database.CategorySidemal || bw.Severity != database.SeverityLow {
+		t.Fatalf("category = %s/%s, want sidemål with the default low severity", bw.Category, bw.Severity)
+	}
+
+	edits := discord.Requests("PATCH", "/channels/"+bottest.RettingChannelID+"/messages/godkjenning")
+	if len(edits) != 1 {
+		t.Fatalf("approval message edited %d times, want 1", len(edits))
+	}
+	var edit discordgo.MessageEdit
+	edits[0].Decode(&edit)
+	if fields := (*edit.Embeds)[0].Fields; len(fields) != 1 || fields[0].Value != "Sidemålsform • låg alvor" {
+		t.Errorf("approval fields = %+v", fields)
+	}
+
+	Ordkategori(b.Session, newMessage("!ordkategori nogen anglisisme høg", "kanal"), b)
+	if bw, _ := db.GetBannedWordByID(int(id)); bw.Category != database.CategoryAnglicism || bw.Severity != database.SeverityHigh {
+		t.Errorf("category = %s/%s, want anglisisme with high severity", bw.Category, bw.Severity)
+	}
+}
+
+func TestOrdkategoriRequiresRole(t *testing.T) {
+	b, discord, db := bottest.New()
+	id, _ := db.AddBannedWordPending("nogen", "", "u1", "brukar", "", "")
+	discord.SetMember("admin")
+
+	Ordkategori(b.Session, newMessage("!ordkategori nogen sidemål", "kanal"), b)
+
+	if bw, _ := db.GetBannedWordByID(int(id)); bw.Category != database.CategoryBokmal {
+		t.Errorf("category changed without a role: %s", bw.Category)
+	}
+
+	// Anyone may look
+	Ordkategori(b.Session, newMessage("!ordkategori nogen", "kanal"), b)
+	embeds := discord.SentEmbeds("kanal")
+	if len(embeds) != 2 || !strings.Contains(embeds[1].Description, "Bokmålsform") {
+		t.Errorf("embeds = %+v", embeds)
+	}
+}
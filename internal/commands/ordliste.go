@@ -0,0 +1,171 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+
+	"askeladden/internal/bot"
+	"askeladden/internal/bot/services"
+	"askeladden/internal/database"
+)
+
+func init() {
+	commands["ordliste"] = Command{
+		name:        "ordliste",
+		description: "Bla i lista over forbodne ord, eventuelt berre ord som byrjar på noko",
+		emoji:       "📖",
+		handler:     Ordliste,
+	}
+}
+
+// WordListButtonPrefix starts the custom ID of the page buttons under a word
+// list. It is followed by "<page>:<prefix>".
+const WordListButtonPrefix = "word_list:"
+
+const (
+	wordListPageSize  = 5
+	wordListMaxPrefix = 50 // Keeps the custom ID within Discord's 100 characters
+)
+
+// Ordliste handsamar ordliste-kommandoen
+func Ordliste(s *discordgo.Session, m *discordgo.MessageCreate, bot *bot.Bot) {
+	prefix := strings.TrimSpace(strings.TrimPrefix(m.Content, strings.Fields(m.Content)[0]))
+
+	embed, components, err := WordListPage(s, bot, prefix, 0)
+	if err != nil {
+		embed := services.CreateBotEmbed(s, "❌ Feil", "Kunne ikkje hente ordlista.", services.EmbedTypeError)
+		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		return
+	}
+
+	_, err = s.ChannelMessageSendComplex(m.ChannelID, &discordgo.MessageSend{
+		Embeds:     []*discordgo.MessageEmbed{embed},
+		Components: components,
+	})
+	if err != nil {
+		log.Printf("Kunne ikkje sende ordlista: %v", err)
+	}
+}
+
+// WordListPage byggjer ei side av lista over forbodne ord som byrjar på prefix,
+// med knappar til førre og neste side når det er fleire sider
+func WordListPage(s *discordgo.Session, bot *bot.Bot, prefix string, page int) (*discordgo.MessageEmbed, []discordgo.MessageComponent, error) {
+	if utf8.RuneCountInString(prefix) > wordListMaxPrefix {
+		prefix = string([]rune(prefix)[:wordListMaxPrefix])
+	}
+
+	words, err := activeWordsWithPrefix(bot, prefix)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	title := "📖 Forbodne ord"
+	if prefix != "" {
+		title += fmt.Sprintf(" på «%s»", prefix)
+	}
+	builder := services.NewEmbedBuilder().
+		SetTitle(title).
+		SetColorByType(services.EmbedTypeInfo).
+		SetAuthorFromBot(s)
+
+	if len(words) == 0 {
+		if prefix != "" {
+			builder.SetDescription(fmt.Sprintf("Ingen forbodne ord byrjar på «%s».", prefix))
+		} else {
+			builder.SetDescription("Det finst ingen forbodne ord enno.")
+		}
+		return builder.Build(), nil, nil
+	}
+
+	pages := (len(words) + wordListPageSize - 1) / wordListPageSize
+	page = max(0, min(page, pages-1))
+
+	end := min((page+1)*wordListPageSize, len(words))
+	for _, bw := range words[page*wordListPageSize : end] {
+		builder.AddField(bw.Word, formatWordListEntry(bw), false)
+	}
+	builder.SetFooter(fmt.Sprintf("Side %d av %d • %d ord", page+1, pages, len(words)), "")
+
+	if pages == 1 {
+		return builder.Build(), nil, nil
+	}
+	components := []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    "Førre",
+					Emoji:    &discordgo.ComponentEmoji{Name: "◀️"},
+					Style:    discordgo.SecondaryButton,
+					CustomID: WordListButtonPrefix + strconv.Itoa(page-1) + ":" + prefix,
+					Disabled: page == 0,
+				},
+				discordgo.Button{
+					Label:    "Neste",
+					Emoji:    &discordgo.ComponentEmoji{Name: "▶️"},
+					Style:    discordgo.SecondaryButton,
+					CustomID: WordListButtonPrefix + strconv.Itoa(page+1) + ":" + prefix,
+					Disabled: page == pages-1,
+				},
+			},
+		},
+	}
+	return builder.Build(), components, nil
+}
+
+// ParseWordListButton splits "<page>:<prefix>" from a page button custom ID
+func ParseWordListButton(customID string) (prefix string, page int, ok bool) {
+	pageText, prefix, ok := strings.Cut(strings.TrimPrefix(customID, WordListButtonPrefix), ":")
+	if !ok {
+		return "", 0, false
+	}
+	page, err := strconv.Atoi(pageText)
+	if err != nil {
+		return "", 0, false
+	}
+	return prefix, page, true
+}
+
+// activeWordsWithPrefix returns the approved words starting with prefix, sorted alphabetically
+func activeWordsWithPrefix(bot *bot.Bot, prefix string) ([]*database.BannedWord, error) {
+	all, err := bot.Database.GetBannedWords()
+	if err != nil {
+		log.Printf("Failed to get banned words: %v", err)
+		return nil, err
+	}
+
+	prefix = strings.ToLower(prefix)
+	var words []*database.BannedWord
+	for _, bw := range all {
+		if bw.IsActive() && strings.HasPrefix(strings.ToLower(bw.Word), prefix) {
+			words = append(words, bw)
+		}
+	}
+	sort.Slice(words, func(i, j int) bool {
+		return strings.ToLower(words[i].Word) < strings.ToLower(words[j].Word)
+	})
+	return words, nil
+}
+
+// formatWordListEntry shows the reason, suggestions and discussion thread of a word
+func formatWordListEntry(bw *database.BannedWord) string {
+	var lines []string
+	if bw.Reason != "" {
+		lines = append(lines, bw.Reason)
+	}
+	if len(bw.Suggestions) > 0 {
+		lines = append(lines, "✏️ Bruk heller: **"+strings.Join(bw.Suggestions, "**, **")+"**")
+	}
+	if bw.ForumThreadID != nil && *bw.ForumThreadID != "" {
+		lines = append(lines, fmt.Sprintf("💬 <#%s>", *bw.ForumThreadID))
+	}
+	if len(lines) == 0 {
+		return "Inga grunngiving."
+	}
+	return strings.Join(lines, "\n")
+}
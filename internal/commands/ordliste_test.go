@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+
+	"askeladden/internal/bot/bottest"
+	"askeladden/internal/database/databasetest"
+)
+
+// addApprovedWords adds fully approved banned words to the fake database
+func addApprovedWords(db *databasetest.DB, words ...string) {
+	for _, word := range words {
+		id, _ := db.AddBannedWordPending(word, "Bokmål", "u1", "brukar", "", "")
+		db.ApproveBannedWordCombined(int(id), []string{"opp"}, []string{"rett"})
+	}
+}
+
+func TestOrdlistePaginates(t *testing.T) {
+	b, discord, db := bottest.New()
+	addApprovedWords(db, "ikke", "hvordan", "Hva", "noen", "bare", "mye", "hvis")
+	db.AddBannedWordPending("ventar", "", "u1", "brukar", "", "")
+
+	Ordliste(b.Session, newMessage("!ordliste", bottest.DefaultChannelID), b)
+
+	embeds := discord.SentEmbeds(bottest.DefaultChannelID)
+	if len(embeds) != 1 || len(embeds[0].Fields) != 5 {
+		t.Fatalf("embeds = %+v", embeds)
+	}
+	var names []string
+	for _, field := range embeds[0].Fields {
+		names = append(names, field.Name)
+	}
+	if strings.Join(names, ",") != "bare,Hva,hvis,hvordan,ikke" {
+		t.Errorf("first page = %v", names)
+	}
+	if embeds[0].Footer == nil || embeds[0].Footer.Text != "Side 1 av 2 • 7 ord" {
+		t.Errorf("footer = %+v", embeds[0].Footer)
+	}
+
+	embed, components, _ := WordListPage(b.Session, b, "", 1)
+	if len(embed.Fields) != 2 || embed.Fields[0].Name != "mye" {
+		t.Errorf("second page = %+v", embed.Fields)
+	}
+	buttons := components[0].(discordgo.ActionsRow).Components
+	previous, next := buttons[0].(discordgo.Button), buttons[1].(discordgo.Button)
+	if previous.CustomID != "word_list:0:" || previous.Disabled || !next.Disabled {
+		t.Errorf("buttons = %+v, %+v", previous, next)
+	}
+}
+
+func TestOrdlisteSearchesByPrefix(t *testing.T) {
+	b, discord, db := bottest.New()
+	addApprovedWords(db, "ikke", "hvordan", "Hva")
+	bw, _ := db.GetBannedWordByID(2)
+	db.UpdateBannedWordSuggestions(bw.ID, []string{"korleis"})
+	db.UpdateBannedWordForumThreadID(bw.ID, "traad")
+
+	Ordliste(b.Session, newMessage("!ordliste HV", bottest.DefaultChannelID), b)
+
+	embeds := discord.SentEmbeds(bottest.DefaultChannelID)
+	if len(embeds) != 1 || len(embeds[0].Fields) != 2 {
+		t.Fatalf("embeds = %+v", embeds)
+	}
+	if entry := embeds[0].Fields[1].Value; !strings.Contains(entry, "**korleis**") || !strings.Contains(entry, "<#traad>") {
+		t.Errorf("entry = %q", entry)
+	}
+
+	embed, components, _ := WordListPage(b.Session, b, "x", 0)
+	if components != nil || !strings.Contains(embed.Description, "«x»") {
+		t.Errorf("empty search = %+v, %+v", embed, components)
+	}
+}
+
+func TestParseWordListButton(t *testing.T) {
+	if prefix, page, ok := ParseWordListButton("word_list:3:i:a"); !ok || page != 3 || prefix != "i:a" {
+		t.Errorf("got %q, %d, %v", prefix, page, ok)
+	}
+	if _, _, ok := ParseWordListButton("word_list:x:"); ok {
+		t.Error("accepted a bad page")
+	}
+}
@@ -0,0 +1,111 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+
+	"askeladden/internal/bannedwords"
+	"askeladden/internal/bot"
+	"askeladden/internal/bot/services"
+	"askeladden/internal/database"
+	"askeladden/internal/permissions"
+)
+
+func init() {
+	commands["ordmønster"] = Command{
+		name:        "ordmønster",
+		description: "Vis eller set fraser, bøyingsformer og stammar* for eit forbode ord (opplysarar og rettskrivarar)",
+		emoji:       "🧩",
+		handler:     Ordmonster,
+		aliases:     []string{"mønster"},
+	}
+}
+
+const ordmonsterUsage = "Bruk: `!ordmønster <ord>` for å sjå mønstera, `!ordmønster <ord> <mønster>, <mønster>, ...` for å setje dei, eller `!ordmønster <ord> -` for å fjerne dei.\n\n" +
+	"Døme: `!ordmønster boken boka, bøker, bok*` eller `!ordmønster forhold i forhold til`"
+
+// Ordmonster handsamar ordmønster-kommandoen
+func Ordmonster(s *discordgo.Session, m *discordgo.MessageCreate, bot *bot.Bot) {
+	permManager := permissions.NewPermissionManager(bot.Config)
+	if permManager.GetUserRole(s, m.GuildID, m.Author.ID) == permissions.RoleNone {
+		embed := services.CreateBotEmbed(s, "⛔ Inga tilgang", "Berre opplysarar og rettskrivarar kan endre ordmønster.", services.EmbedTypeError)
+		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		return
+	}
+
+	parts := strings.Fields(m.Content)
+	if len(parts) < 2 {
+		embed := services.CreateBotEmbed(s, "❓ Feil", ordmonsterUsage, services.EmbedTypeError)
+		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		return
+	}
+
+	bannedWord := findReportedWord(s, m, bot, parts[1])
+	if bannedWord == nil {
+		return
+	}
+
+	// Only the word: show the current patterns
+	if len(parts) == 2 {
+		embed := services.CreateBotEmbed(s, "🧩 Mønster for «"+bannedWord.Word+"»", formatPatterns(bannedWord.Patterns), services.EmbedTypeInfo)
+		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		return
+	}
+
+	var patterns []string
+	list := strings.Join(parts[2:], " ")
+	if list != "-" {
+		var err error
+		patterns, err = bannedwords.ParsePatternList(list)
+		if err != nil {
+			embed := services.CreateBotEmbed(s, "❓ Ugyldig mønster", err.Error()+"\n\n"+ordmonsterUsage, services.EmbedTypeError)
+			s.ChannelMessageSendEmbed(m.ChannelID, embed)
+			return
+		}
+	}
+
+	if err := bot.Database.UpdateBannedWordPatterns(bannedWord.ID, patterns); err != nil {
+		log.Printf("Failed to update patterns for banned word %d: %v", bannedWord.ID, err)
+		embed := services.CreateBotEmbed(s, "❌ Feil", "Kunne ikkje lagre mønstera.", services.EmbedTypeError)
+		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		return
+	}
+	bot.BannedWords.Invalidate()
+
+	embed := services.CreateBotEmbed(s, "✅ Mønster oppdaterte for «"+bannedWord.Word+"»", formatPatterns(patterns), services.EmbedTypeSuccess)
+	s.ChannelMessageSendEmbed(m.ChannelID, embed)
+}
+
+// findReportedWord looks up a reported banned word for a command argument and
+// tells the user if it can't be found. Returns nil if the command should stop.
+func findReportedWord(s *discordgo.Session, m *discordgo.MessageCreate, bot *bot.Bot, word string) *database.BannedWord {
+	word = strings.ToLower(word)
+	isBanned, bannedWord, err := bot.Database.IsBannedWord(word)
+	if err != nil {
+		log.Printf("Failed to look up banned word '%s': %v", word, err)
+		embed := services.CreateBotEmbed(s, "❌ Feil", "Kunne ikkje slå opp ordet.", services.EmbedTypeError)
+		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		return nil
+	}
+	if !isBanned {
+		embed := services.CreateBotEmbed(s, "❓ Ukjent ord", fmt.Sprintf("«%s» er ikkje rapportert som forbode ord.", word), services.EmbedTypeError)
+		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		return nil
+	}
+	return bannedWord
+}
+
+// formatPatterns lists patterns for an embed description
+func formatPatterns(patterns []string) string {
+	if len(patterns) == 0 {
+		return "Ingen ekstra mønster. Berre sjølve ordet vert fanga opp."
+	}
+	var lines []string
+	for _, p := range patterns {
+		lines = append(lines, "• `"+p+"`")
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"askeladden/internal/bot/bottest"
+)
+
+func TestOrdmonsterSetsPatterns(t *testing.T) {
+	b, discord, db := bottest.New()
+	id, _ := db.AddBannedWordPending("boken", "", "u1", "brukar", "", "")
+	db.ApproveBannedWordCombined(int(id), []string{"opp"}, []string{"rett"})
+	discord.SetMember("admin", bottest.RettskrivarRoleID)
+
+	if found := b.BannedWords.FindAll("Eg las bøkene i går"); len(found) != 0 {
+		t.Fatal("inflected form matched before patterns were set")
+	}
+
+	Ordmonster(b.Session, newMessage("!ordmønster boken boka, bøk*, i boka mi", "kanal"), b)
+
+	bw, _ := db.GetBannedWordByID(int(id))
+	if strings.Join(bw.Patterns, "|") != "boka|bøk*|i boka mi" {
+		t.Fatalf("patterns = %q", bw.Patterns)
+	}
+	if found := b.BannedWords.FindAll("Eg las bøkene i går"); len(found) != 1 {
+		t.Error("matcher was not invalidated after patterns changed")
+	}
+
+	Ordmonster(b.Session, newMessage("!ordmønster boken -", "kanal"), b)
+	if bw, _ := db.GetBannedWordByID(int(id)); len(bw.Patterns) != 0 {
+		t.Errorf("patterns not cleared: %q", bw.Patterns)
+	}
+}
+
+func TestOrdmonsterRejectsInvalidPattern(t *testing.T) {
+	b, discord, db := bottest.New()
+	id, _ := db.AddBannedWordPending("boken", "", "u1", "brukar", "", "")
+	discord.SetMember("admin", bottest.OpplysarRoleID)
+
+	Ordmonster(b.Session, newMessage("!ordmønster boken bo*ka", "kanal"), b)
+
+	if bw, _ := db.GetBannedWordByID(int(id)); len(bw.Patterns) != 0 {
+		t.Errorf("invalid pattern was stored: %q", bw.Patterns)
+	}
+	embeds := discord.SentEmbeds("kanal")
+	if len(embeds) != 1 || !strings.Contains(embeds[0].Title, "Ugyldig") {
+		t.Fatalf("embeds = %+v", embeds)
+	}
+}
+
+func TestOrdmonsterRequiresRole(t *testing.T) {
+	b, discord, db := bottest.New()
+	id, _ := db.AddBannedWordPending("boken", "", "u1", "brukar", "", "")
+	discord.SetMember("admin")
+
+	Ordmonster(b.Session, newMessage("!ordmønster boken boka", "kanal"), b)
+
+	if bw, _ := db.GetBannedWordByID(int(id)); len(bw.Patterns) != 0 {
+		t.Errorf("patterns changed by user without role: %q", bw.Patterns)
+	}
+}
@@ -0,0 +1,176 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"askeladden/internal/bot"
+	"askeladden/internal/bot/services"
+	"askeladden/internal/database"
+)
+
+func init() {
+	commands["ordstatistikk"] = Command{
+		name:        "ordstatistikk",
+		description: "Vis dei mest brukte forbodne orda, utviklinga veke for veke og din eigen historikk",
+		emoji:       "📊",
+		handler:     Ordstatistikk,
+		aliases:     []string{"ordstat"},
+	}
+}
+
+const (
+	statsTopWords  = 5                   // Words in the server-wide top list
+	statsUserWords = 3                   // Words in the caller's own top list
+	statsWeeks     = 4                   // Weeks shown in the trends
+	statsPeriod    = 30 * 24 * time.Hour // Period for the top lists
+)
+
+// week is seven days, counted back from now rather than from Monday, so the
+// latest week is never just a day or two long
+const week = 7 * 24 * time.Hour
+
+// Ordstatistikk handsamar ordstatistikk-kommandoen
+func Ordstatistikk(s *discordgo.Session, m *discordgo.MessageCreate, bot *bot.Bot) {
+	now := time.Now()
+
+	topWords, err := bot.Database.GetTopBannedWords("", now.Add(-statsPeriod), statsTopWords)
+	if err != nil {
+		sendStatsError(s, m)
+		return
+	}
+	weekly, err := weeklyHits(bot.Database, "", now)
+	if err != nil {
+		sendStatsError(s, m)
+		return
+	}
+	userWords, err := bot.Database.GetTopBannedWords(m.Author.ID, now.Add(-statsPeriod), statsUserWords)
+	if err != nil {
+		sendStatsError(s, m)
+		return
+	}
+	userWeekly, err := weeklyHits(bot.Database, m.Author.ID, now)
+	if err != nil {
+		sendStatsError(s, m)
+		return
+	}
+
+	builder := services.NewEmbedBuilder().
+		SetTitle("📊 Ordstatistikk").
+		SetColorByType(services.EmbedTypeInfo).
+		SetAuthorFromBot(s).
+		SetTimestamp()
+
+	if len(topWords) == 0 {
+		builder.SetDescription("Ingen forbodne ord er oppdaga dei siste 30 dagane. 🎉")
+	} else {
+		builder.AddField("🏆 Mest brukte ord (30 dagar)", formatTopWords(topWords), false)
+		builder.AddField("📈 Veke for veke", formatWeeklyHits(weekly), false)
+	}
+	builder.AddField("🪞 Rettingshistorikken din", formatUserHistory(userWords, userWeekly), false)
+
+	s.ChannelMessageSendEmbed(m.ChannelID, builder.Build())
+}
+
+// weeklyHits counts hits per week, the latest week first
+func weeklyHits(db database.DatabaseIface, userID string, now time.Time) ([]int, error) {
+	counts := make([]int, statsWeeks)
+	for i := range counts {
+		to := now.Add(-time.Duration(i) * week)
+		count, err := db.CountBannedWordHits(userID, to.Add(-week), to)
+		if err != nil {
+			log.Printf("Failed to count banned word hits: %v", err)
+			return nil, err
+		}
+		counts[i] = count
+	}
+	return counts, nil
+}
+
+// formatTopWords lists words with their number of hits
+func formatTopWords(words []database.BannedWordHitCount) string {
+	var lines []string
+	for i, w := range words {
+		lines = append(lines, fmt.Sprintf("%d. **%s** – %s", i+1, w.Word, formatTimes(w.Count)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// formatWeeklyHits shows each week with the change from the week before
+func formatWeeklyHits(counts []int) string {
+	var lines []string
+	for i, count := range counts {
+		line := fmt.Sprintf("%s: %s", weekLabel(i), formatTimes(count))
+		if i+1 < len(counts) {
+			line += " " + trendMarker(count, counts[i+1])
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// formatUserHistory shows the caller's own words and whether they are improving
+func formatUserHistory(words []database.BannedWordHitCount, counts []int) string {
+	total := 0
+	for _, count := range counts {
+		total += count
+	}
+	if total == 0 {
+		return "Du har ikkje brukt nokon forbodne ord dei siste fire vekene. 🎉"
+	}
+
+	text := formatWeeklyHits(counts)
+	if len(words) > 0 {
+		var list []string
+		for _, w := range words {
+			list = append(list, fmt.Sprintf("**%s** (%d)", w.Word, w.Count))
+		}
+		text += "\n\nOrda du oftast brukar: " + strings.Join(list, ", ")
+	}
+	if counts[0] < counts[1] {
+		text += "\n\nDu brukar færre forbodne ord enn veka før. Godt jobba! 💪"
+	}
+	return text
+}
+
+// weekLabel names a week counted back from now
+func weekLabel(weeksAgo int) string {
+	switch weeksAgo {
+	case 0:
+		return "Siste 7 dagar"
+	case 1:
+		return "Veka før"
+	default:
+		return fmt.Sprintf("For %d veker sidan", weeksAgo)
+	}
+}
+
+// trendMarker compares a week with the week before
+func trendMarker(count, previous int) string {
+	switch {
+	case count < previous:
+		return fmt.Sprintf("▼ %d", previous-count)
+	case count > previous:
+		return fmt.Sprintf("▲ %d", count-previous)
+	default:
+		return "＝"
+	}
+}
+
+// formatTimes formats a number of hits
+func formatTimes(count int) string {
+	if count == 1 {
+		return "1 gong"
+	}
+	return fmt.Sprintf("%d gonger", count)
+}
+
+// sendStatsError tells the user the statistics could not be fetched
+func sendStatsError(s *discordgo.Session, m *discordgo.MessageCreate) {
+	embed := services.CreateBotEmbed(s, "❌ Feil", "Kunne ikkje hente statistikken.", services.EmbedTypeError)
+	s.ChannelMessageSendEmbed(m.ChannelID, embed)
+}
@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"askeladden/internal/bot/bottest"
+)
+
+func TestOrdstatistikk(t *testing.T) {
+	b, discord, db := bottest.New()
+	ikke, _ := db.AddBannedWordPending("ikke", "", "u1", "brukar", "", "")
+	hvordan, _ := db.AddBannedWordPending("hvordan", "", "u1", "brukar", "", "")
+
+	// Two hits by the caller last week, one this week, and two by someone else
+	now := time.Now()
+	db.Now = func() time.Time { return now.Add(-8 * 24 * time.Hour) }
+	db.AddBannedWordHit(int(ikke), "admin", "c", "m1")
+	db.AddBannedWordHit(int(hvordan), "admin", "c", "m2")
+	db.Now = func() time.Time { return now.Add(-time.Hour) }
+	db.AddBannedWordHit(int(ikke), "admin", "c", "m3")
+	db.AddBannedWordHit(int(ikke), "andre", "c", "m4")
+	db.AddBannedWordHit(int(hvordan), "andre", "c", "m5")
+
+	Ordstatistikk(b.Session, newMessage("!ordstatistikk", bottest.DefaultChannelID), b)
+
+	embeds := discord.SentEmbeds(bottest.DefaultChannelID)
+	if len(embeds) != 1 || len(embeds[0].Fields) != 3 {
+		t.Fatalf("embeds = %+v", embeds)
+	}
+	top, weekly, history := embeds[0].Fields[0].Value, embeds[0].Fields[1].Value, embeds[0].Fields[2].Value
+
+	if !strings.HasPrefix(top, "1. **ikke** – 3 gonger\n2. **hvordan** – 2 gonger") {
+		t.Errorf("top words = %q", top)
+	}
+	if !strings.HasPrefix(weekly, "Siste 7 dagar: 3 gonger ▲ 1\nVeka før: 2 gonger ▲ 2") {
+		t.Errorf("weekly = %q", weekly)
+	}
+	if !strings.HasPrefix(history, "Siste 7 dagar: 1 gong ▼ 1") || !strings.Contains(history, "**ikke** (2)") || !strings.Contains(history, "Godt jobba") {
+		t.Errorf("history = %q", history)
+	}
+}
+
+func TestOrdstatistikkWithoutHits(t *testing.T) {
+	b, discord, _ := bottest.New()
+
+	Ordstatistikk(b.Session, newMessage("!ordstatistikk", bottest.DefaultChannelID), b)
+
+	embeds := discord.SentEmbeds(bottest.DefaultChannelID)
+	if len(embeds) != 1 || embeds[0].Description == "" || len(embeds[0].Fields) != 1 {
+		t.Fatalf("embeds = %+v", embeds)
+	}
+	if !strings.Contains(embeds[0].Fields[0].Value, "ikkje brukt") {
+		t.Errorf("history = %q", embeds[0].Fields[0].Value)
+	}
+}
@@ -4,9 +4,12 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"askeladden/internal/bot"
 	"askeladden/internal/bot/services"
+	"askeladden/internal/database"
+	"askeladden/internal/questions"
 	"github.com/bwmarrin/discordgo"
 )
 
@@ -31,9 +34,15 @@ func handlePoke(s *discordgo.Session, m *discordgo.MessageCreate, bot *bot.Bot)
 		pokeAlle = true
 	}
 
-	question, err := db.GetLeastAskedApprovedQuestion()
+	question, err := questions.Next(db, questions.FromConfig(bot.Config), time.Now())
+	if err == questions.ErrNoneReady {
+		log.Println("Every approved question is still in cooldown")
+		embed := services.CreateBotEmbed(s, "⏳ Alle spørsmåla kviler", "Alle godkjente spørsmål er stilte for nyleg og kan ikkje stillast att før `cooldown_days` har gått. Legg til fleire spørsmål eller vent litt.", services.EmbedTypeWarning)
+		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		return
+	}
 	if err != nil {
-		log.Printf("Failed to get least asked question: %v", err)
+		log.Printf("Failed to pick daily question: %v", err)
 		embed := services.CreateBotEmbed(s, "❌ Feil", "Feil ved henting av spørsmål frå databasen.", services.EmbedTypeError)
 		s.ChannelMessageSendEmbed(m.ChannelID, embed)
 		return
@@ -63,7 +72,8 @@ func handlePoke(s *discordgo.Session, m *discordgo.MessageCreate, bot *bot.Bot)
 		mention = fmt.Sprintf("<@%s>", question.AuthorID)
 	}
 
-	services.SendDailyQuestion(bot, question, mention)
+	sent := services.SendDailyQuestion(bot, question, mention)
+	services.RecordQuestionPost(bot, question, sent, database.QuestionTriggerPoke, m.Author.ID)
 
 	log.Printf("Daily question manually triggered: %s (asked %d times total)", question.Question, question.TimesAsked+1)
 
@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"askeladden/internal/bot/bottest"
+	"askeladden/internal/questions"
+)
+
+func TestPokeSendsLeastAskedQuestion(t *testing.T) {
+	b, discord, db := bottest.New()
+	for _, q := range []string{"Første?", "Andre?"} {
+		id, _ := db.AddQuestion(q, "forfattar", "brukar", q, "c")
+		db.ApproveQuestion(int(id), "opplysar")
+	}
+	first, _ := db.GetQuestionByMessageID("Første?")
+	db.IncrementQuestionUsage(first.ID)
+
+	handlePoke(b.Session, newMessage("!poke", "admin-kanal"), b)
+
+	asked, _ := db.GetQuestionByMessageID("Andre?")
+	if asked.TimesAsked != 1 || asked.LastAskedAt == nil {
+		t.Fatalf("least asked question not marked as asked: %+v", asked)
+	}
+
+	sent := discord.SentMessages(bottest.DefaultChannelID)
+	if len(sent) != 1 {
+		t.Fatalf("expected one daily question in the default channel, got %d", len(sent))
+	}
+	if sent[0].Content != "<@forfattar>" || sent[0].Embeds[0].Description != "Andre?" {
+		t.Errorf("daily question message = %q / %q", sent[0].Content, sent[0].Embeds[0].Description)
+	}
+	if stats := discord.SentEmbeds(bottest.LogChannelID); len(stats) != 1 {
+		t.Errorf("expected stats in the log channel, got %d embeds", len(stats))
+	}
+}
+
+func TestPokeAlleMentionsEveryone(t *testing.T) {
+	b, discord, db := bottest.New()
+	id, _ := db.AddQuestion("Spørsmål?", "forfattar", "brukar", "m", "c")
+	db.ApproveQuestion(int(id), "opplysar")
+
+	handlePoke(b.Session, newMessage("!poke alle", "admin-kanal"), b)
+
+	sent := discord.SentMessages(bottest.DefaultChannelID)
+	if len(sent) != 1 || sent[0].Content != "@everyone" {
+		t.Fatalf("daily question messages = %+v", sent)
+	}
+}
+
+func TestPokeWithoutApprovedQuestions(t *testing.T) {
+	b, discord, db := bottest.New()
+	db.AddQuestion("Ventar?", "forfattar", "brukar", "m", "c")
+
+	handlePoke(b.Session, newMessage("!poke", "admin-kanal"), b)
+
+	if sent := discord.SentMessages(bottest.DefaultChannelID); len(sent) != 0 {
+		t.Errorf("no question should be posted, got %d messages", len(sent))
+	}
+	embeds := discord.SentEmbeds("admin-kanal")
+	if len(embeds) != 1 || !strings.Contains(embeds[0].Title, "Ingen godkjente") {
+		t.Errorf("warning embeds = %+v", embeds)
+	}
+}
+
+func TestPokeWhenEveryQuestionIsInCooldown(t *testing.T) {
+	b, discord, db := bottest.New()
+	b.Config.Scheduler.Strategy = questions.StrategyCooldown
+	addApprovedQuestions(db, "Første?")
+	db.IncrementQuestionUsage(1)
+
+	handlePoke(b.Session, newMessage("!poke", "admin-kanal"), b)
+
+	if sent := discord.SentMessages(bottest.DefaultChannelID); len(sent) != 0 {
+		t.Errorf("no question should be posted, got %d messages", len(sent))
+	}
+	embeds := discord.SentEmbeds("admin-kanal")
+	if len(embeds) != 1 || !strings.Contains(embeds[0].Title, "kviler") {
+		t.Errorf("warning embeds = %+v", embeds)
+	}
+}
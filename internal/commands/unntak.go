@@ -0,0 +1,190 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"unicode"
+
+	"github.com/bwmarrin/discordgo"
+
+	"askeladden/internal/bannedwords"
+	"askeladden/internal/bot"
+	"askeladden/internal/bot/services"
+	"askeladden/internal/permissions"
+)
+
+func init() {
+	commands["unntak"] = Command{
+		name:        "unntak",
+		description: "Vis eller set uttrykk og namn der eit forbode ord er lov, eller for alle ord (rettskrivarar)",
+		emoji:       "🛟",
+		handler:     Unntak,
+	}
+}
+
+// allowlistArg selects the allowlist that applies to every word
+const allowlistArg = "alle"
+
+const unntakUsage = "Bruk: `!unntak <ord>` for å sjå unntaka for eit ord, `!unntak <ord> <unntak>, <unntak>, ...` for å setje dei, eller `!unntak <ord> -` for å fjerne dei.\n" +
+	"For alle ord: `!unntak alle` for å sjå lista, `!unntak alle + <unntak>` for å leggje til og `!unntak alle - <unntak>` for å fjerne.\n\n" +
+	"Eit unntak er ei frase (som i `!ordmønster`) eller eit regulært uttrykk mellom skråstrekar. Ordet vert ikkje fanga opp inne i eit unntak.\n\n" +
+	"Døme: `!unntak ikke Ikke Ro AS` eller `!unntak alle + /«[^»]*»/` for sitat i hermeteikn"
+
+// Unntak handsamar unntak-kommandoen
+func Unntak(s *discordgo.Session, m *discordgo.MessageCreate, bot *bot.Bot) {
+	parts := strings.Fields(m.Content)
+	if len(parts) < 2 {
+		embed := services.CreateBotEmbed(s, "❓ Feil", unntakUsage, services.EmbedTypeError)
+		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		return
+	}
+
+	if strings.ToLower(parts[1]) == allowlistArg {
+		allowlist(s, m, bot, parts[2:])
+		return
+	}
+
+	bannedWord := findReportedWord(s, m, bot, parts[1])
+	if bannedWord == nil {
+		return
+	}
+
+	// Only the word: anyone may see the current exceptions
+	if len(parts) == 2 {
+		embed := services.CreateBotEmbed(s, "🛟 Unntak for «"+bannedWord.Word+"»", formatExceptions(bannedWord.Exceptions), services.EmbedTypeInfo)
+		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		return
+	}
+
+	if !canEditExceptions(s, m, bot) {
+		return
+	}
+
+	var exceptions []string
+	list := afterFields(m.Content, 2)
+	if list != "-" {
+		var err error
+		exceptions, err = bannedwords.ParseExceptionList(list)
+		if err != nil {
+			embed := services.CreateBotEmbed(s, "❓ Ugyldig unntak", err.Error()+"\n\n"+unntakUsage, services.EmbedTypeError)
+			s.ChannelMessageSendEmbed(m.ChannelID, embed)
+			return
+		}
+	}
+
+	if err := bot.Database.UpdateBannedWordExceptions(bannedWord.ID, exceptions); err != nil {
+		log.Printf("Failed to update exceptions for banned word %d: %v", bannedWord.ID, err)
+		embed := services.CreateBotEmbed(s, "❌ Feil", "Kunne ikkje lagre unntaka.", services.EmbedTypeError)
+		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		return
+	}
+	bot.BannedWords.Invalidate()
+
+	embed := services.CreateBotEmbed(s, "✅ Unntak oppdaterte for «"+bannedWord.Word+"»", formatExceptions(exceptions), services.EmbedTypeSuccess)
+	s.ChannelMessageSendEmbed(m.ChannelID, embed)
+}
+
+// allowlist shows or changes the exceptions for every word. Args are the
+// words after "alle".
+func allowlist(s *discordgo.Session, m *discordgo.MessageCreate, bot *bot.Bot, args []string) {
+	if len(args) == 0 {
+		phrases, err := bot.Database.GetAllowedPhrases()
+		if err != nil {
+			embed := services.CreateBotEmbed(s, "❌ Feil", "Kunne ikkje hente unntaka.", services.EmbedTypeError)
+			s.ChannelMessageSendEmbed(m.ChannelID, embed)
+			return
+		}
+		var list []string
+		for _, p := range phrases {
+			list = append(list, p.Phrase)
+		}
+		embed := services.CreateBotEmbed(s, "🛟 Unntak for alle ord", formatExceptions(list), services.EmbedTypeInfo)
+		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		return
+	}
+
+	if !canEditExceptions(s, m, bot) {
+		return
+	}
+
+	operation := args[0]
+	if (operation != "+" && operation != "-") || len(args) < 2 {
+		embed := services.CreateBotEmbed(s, "❓ Feil", unntakUsage, services.EmbedTypeError)
+		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		return
+	}
+
+	exception, err := bannedwords.ParseException(afterFields(m.Content, 3))
+	if err != nil {
+		embed := services.CreateBotEmbed(s, "❓ Ugyldig unntak", err.Error()+"\n\n"+unntakUsage, services.EmbedTypeError)
+		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		return
+	}
+	phrase := exception.String()
+
+	var title string
+	if operation == "+" {
+		if err := bot.Database.AddAllowedPhrase(phrase, m.Author.ID); err != nil {
+			embed := services.CreateBotEmbed(s, "❌ Feil", fmt.Sprintf("Kunne ikkje leggje til `%s`. Finst det alt?", phrase), services.EmbedTypeError)
+			s.ChannelMessageSendEmbed(m.ChannelID, embed)
+			return
+		}
+		title = "✅ Unntak lagt til for alle ord"
+	} else {
+		removed, err := bot.Database.RemoveAllowedPhrase(phrase)
+		if err != nil {
+			embed := services.CreateBotEmbed(s, "❌ Feil", "Kunne ikkje fjerne unntaket.", services.EmbedTypeError)
+			s.ChannelMessageSendEmbed(m.ChannelID, embed)
+			return
+		}
+		if !removed {
+			embed := services.CreateBotEmbed(s, "❓ Ukjent unntak", fmt.Sprintf("`%s` er ikkje eit unntak for alle ord.", phrase), services.EmbedTypeError)
+			s.ChannelMessageSendEmbed(m.ChannelID, embed)
+			return
+		}
+		title = "✅ Unntak fjerna for alle ord"
+	}
+	bot.BannedWords.Invalidate()
+
+	embed := services.CreateBotEmbed(s, title, "`"+phrase+"`", services.EmbedTypeSuccess)
+	s.ChannelMessageSendEmbed(m.ChannelID, embed)
+}
+
+// canEditExceptions checks that the author is a rettskrivar and tells them if not
+func canEditExceptions(s *discordgo.Session, m *discordgo.MessageCreate, bot *bot.Bot) bool {
+	permManager := permissions.NewPermissionManager(bot.Config)
+	if !permManager.HasRettskrivarRole(s, m.GuildID, m.Author.ID) {
+		embed := services.CreateBotEmbed(s, "⛔ Inga tilgang", "Berre rettskrivarar kan endre unntak.", services.EmbedTypeError)
+		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		return false
+	}
+	return true
+}
+
+// formatExceptions lists exceptions for an embed description
+func formatExceptions(exceptions []string) string {
+	if len(exceptions) == 0 {
+		return "Ingen unntak."
+	}
+	var lines []string
+	for _, e := range exceptions {
+		lines = append(lines, "• `"+e+"`")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// afterFields returns the content after the first n fields as it was
+// written, so newlines and repeated spaces inside a list are kept
+func afterFields(content string, n int) string {
+	rest := content
+	for i := 0; i < n; i++ {
+		rest = strings.TrimLeftFunc(rest, unicode.IsSpace)
+		end := strings.IndexFunc(rest, unicode.IsSpace)
+		if end < 0 {
+			return ""
+		}
+		rest = rest[end:]
+	}
+	return strings.TrimSpace(rest)
+}
@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"askeladden/internal/bot/bottest"
+)
+
+func TestUnntakSetsWordExceptions(t *testing.T) {
+	b, discord, db := bottest.New()
+	addApprovedWords(db, "ikke")
+	discord.SetMember("admin", bottest.RettskrivarRoleID)
+
+	Unntak(b.Session, newMessage("!unntak ikke Ikke Ro AS, /ikke{1,2}/", "kanal"), b)
+
+	bw, _ := db.GetBannedWordByID(1)
+	if strings.Join(bw.Exceptions, "|") != "Ikke Ro AS|/ikke{1,2}/" {
+		t.Fatalf("exceptions = %q", bw.Exceptions)
+	}
+	if found := b.BannedWords.FindAll("Eg jobbar i Ikke Ro AS"); len(found) != 0 {
+		t.Error("matcher not reloaded with the new exceptions")
+	}
+
+	Unntak(b.Session, newMessage("!unntak ikke", "kanal"), b)
+	embeds := discord.SentEmbeds("kanal")
+	if len(embeds) != 2 || !strings.Contains(embeds[1].Description, "`Ikke Ro AS`") {
+		t.Fatalf("embeds = %+v", embeds)
+	}
+
+	Unntak(b.Session, newMessage("!unntak ikke -", "kanal"), b)
+	if bw, _ := db.GetBannedWordByID(1); len(bw.Exceptions) != 0 {
+		t.Errorf("exceptions not cleared: %q", bw.Exceptions)
+	}
+}
+
+func TestUnntakKeepsNewlineSeparatedList(t *testing.T) {
+	b, discord, db := bottest.New()
+	addApprovedWords(db, "ikke")
+	discord.SetMember("admin", bottest.RettskrivarRoleID)
+
+	Unntak(b.Session, newMessage("!unntak ikke\nIkke Ro AS\n/ikke  ro/\nIkke-Røyk", "kanal"), b)
+
+	bw, _ := db.GetBannedWordByID(1)
+	if strings.Join(bw.Exceptions, "|") != "Ikke Ro AS|/ikke  ro/|Ikke-Røyk" {
+		t.Fatalf("exceptions = %q", bw.Exceptions)
+	}
+}
+
+func TestUnntakManagesAllowlist(t *testing.T) {
+	b, discord, db := bottest.New()
+	addApprovedWords(db, "ikke")
+	discord.SetMember("admin", bottest.RettskrivarRoleID)
+
+	Unntak(b.Session, newMessage("!unntak alle + /«[^»]*»/", "kanal"), b)
+	if found := b.BannedWords.FindAll("Han skreiv «ikke»"); len(found) != 0 {
+		t.Error("quoted word matched after it was allowlisted")
+	}
+
+	Unntak(b.Session, newMessage("!unntak alle + /«[^»]*»/", "kanal"), b)
+	Unntak(b.Session, newMessage("!unntak alle", "kanal"), b)
+	embeds := discord.SentEmbeds("kanal")
+	if len(embeds) != 3 || !strings.Contains(embeds[1].Title, "Feil") || !strings.Contains(embeds[2].Description, "`/«[^»]*»/`") {
+		t.Fatalf("embeds = %+v", embeds)
+	}
+
+	Unntak(b.Session, newMessage("!unntak alle - /«[^»]*»/", "kanal"), b)
+	if phrases, _ := db.GetAllowedPhrases(); len(phrases) != 0 {
+		t.Errorf("allowlist = %+v, want empty", phrases)
+	}
+	if found := b.BannedWords.FindAll("Han skreiv «ikke»"); len(found) != 1 {
+		t.Error("quoted word not matched after the allowlist entry was removed")
+	}
+}
+
+func TestUnntakRequiresRettskrivar(t *testing.T) {
+	b, discord, db := bottest.New()
+	addApprovedWords(db, "ikke")
+	discord.SetMember("admin", bottest.OpplysarRoleID)
+
+	Unntak(b.Session, newMessage("!unntak ikke Ikke Ro AS", "kanal"), b)
+	Unntak(b.Session, newMessage("!unntak alle + Ikke Ro AS", "kanal"), b)
+
+	if bw, _ := db.GetBannedWordByID(1); len(bw.Exceptions) != 0 {
+		t.Errorf("exceptions changed by opplysar: %q", bw.Exceptions)
+	}
+	if phrases, _ := db.GetAllowedPhrases(); len(phrases) != 0 {
+		t.Errorf("allowlist changed by opplysar: %+v", phrases)
+	}
+}
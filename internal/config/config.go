@@ -5,6 +5,9 @@ package config
 
 import (
 	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"gopkg.in/yaml.v3"
 )
@@ -23,14 +26,22 @@ type Config struct {
 	} `yaml:"approval"`
 
 	BannedWords struct {
-		ApprovalChannelID string `yaml:"approvalChannelID"`
-		RettskrivarRoleID string `yaml:"rettskrivarRoleID"`
+		ApprovalChannelID string       `yaml:"approvalChannelID"`
+		RettskrivarRoleID string       `yaml:"rettskrivarRoleID"`
+		Warnings          WarningRules `yaml:"warnings"`
 	} `yaml:"bannedwords"`
 
 	Grammar struct {
-		ChannelID string `yaml:"channelID"`
+		ChannelID string    `yaml:"channelID"`
+		Tags      ForumTags `yaml:"tags"`
 	} `yaml:"grammar"`
 
+	// Local word lists for checking reported words, see package dictionary
+	Dictionary struct {
+		NynorskPath string `yaml:"nynorskPath"` // One word per line, or a Norsk Ordbank full-form list
+		BokmalPath  string `yaml:"bokmalPath"`  // The same for bokmål
+	} `yaml:"dictionary"`
+
 	Starboard struct {
 		ChannelID string `yaml:"channelID"`
 		Threshold int    `yaml:"threshold"`
@@ -38,6 +49,8 @@ type Config struct {
 	} `yaml:"starboard"`
 
 	Database struct {
+		Driver   string `yaml:"driver"` // "mysql" (default) or "sqlite"
+		Path     string `yaml:"path"`   // SQLite file, used when driver is "sqlite"
 		User     string `yaml:"user"`
 		Password string `yaml:"password"`
 		Host     string `yaml:"host"`
@@ -46,12 +59,15 @@ type Config struct {
 	} `yaml:"database"`
 
 	Scheduler struct {
-		CronString      string `yaml:"cron_string"`
-		Timezone        string `yaml:"timezone"`
-		MorningTime     string `yaml:"morning_time"`
-		EveningTime     string `yaml:"evening_time"`
-		InactivityHours int    `yaml:"inactivity_hours"`
-		Enabled         bool   `yaml:"enabled"`
+		CronString      string        `yaml:"cron_string"`
+		Timezone        string        `yaml:"timezone"`
+		MorningTime     string        `yaml:"morning_time"`
+		EveningTime     string        `yaml:"evening_time"`
+		InactivityHours int           `yaml:"inactivity_hours"`
+		Enabled         bool          `yaml:"enabled"`
+		Strategy        string        `yaml:"strategy"`      // How questions are picked, see package questions (default least_asked)
+		CooldownDays    int           `yaml:"cooldown_days"` // Days before a question may be asked again with the cooldown strategy (default 30)
+		AnswerThreads   AnswerThreads `yaml:"answer_threads"`
 	} `yaml:"scheduler"`
 
 	// Reaction emojis
@@ -66,6 +82,169 @@ type Config struct {
 	AISlopWarningText string `yaml:"aiSlopWarningText"`
 }
 
+// WarningRules avgjer kva kanalar som får åtvaringar om forbodne ord, og kor
+// ofte. Kanalreglar går føre kategorireglar, og forbod går føre løyve. Er begge
+// løyvelistene tomme, får alle kanalar som ikkje er forbodne åtvaringar.
+type WarningRules struct {
+	AllowChannels   []string `yaml:"allowChannels"`
+	DenyChannels    []string `yaml:"denyChannels"`
+	AllowCategories []string `yaml:"allowCategories"`
+	DenyCategories  []string `yaml:"denyCategories"`
+
+	// Cooldowns; 0 gives the default
+	UserCooldownMinutes    int `yaml:"userCooldownMinutes"`    // Same user and word (default 30)
+	ChannelCooldownSeconds int `yaml:"channelCooldownSeconds"` // Any warning in a channel (default 60)
+}
+
+// Default warning cooldowns
+const (
+	DefaultUserCooldown    = 30 * time.Minute
+	DefaultChannelCooldown = 60 * time.Second
+)
+
+// UserCooldown is how long a user is not warned again about the same word
+func (w WarningRules) UserCooldown() time.Duration {
+	if w.UserCooldownMinutes <= 0 {
+		return DefaultUserCooldown
+	}
+	return time.Duration(w.UserCooldownMinutes) * time.Minute
+}
+
+// ChannelCooldown is how long after a warning no new warning is posted in the same channel
+func (w WarningRules) ChannelCooldown() time.Duration {
+	if w.ChannelCooldownSeconds <= 0 {
+		return DefaultChannelCooldown
+	}
+	return time.Duration(w.ChannelCooldownSeconds) * time.Second
+}
+
+// Allows reports whether warnings may be sent in a channel. channelIDs is the
+// channel itself and, for threads, its parent channel.
+func (w WarningRules) Allows(channelIDs []string, categoryID string) bool {
+	for _, id := range channelIDs {
+		if contains(w.DenyChannels, id) {
+			return false
+		}
+	}
+	for _, id := range channelIDs {
+		if contains(w.AllowChannels, id) {
+			return true
+		}
+	}
+	if categoryID != "" && contains(w.DenyCategories, categoryID) {
+		return false
+	}
+	if categoryID != "" && contains(w.AllowCategories, categoryID) {
+		return true
+	}
+	return len(w.AllowChannels) == 0 && len(w.AllowCategories) == 0
+}
+
+// AnswerThreads styrer trådane som vert starta på kvart dagleg spørsmål, slik
+// at svara held seg saman i staden for å blande seg med resten av praten.
+type AnswerThreads struct {
+	Enabled bool `yaml:"enabled"`
+	// Thread name; {question} is the question and {date} the day it is posted
+	// (default "Svar: {question}")
+	NameTemplate string `yaml:"name_template"`
+	// Minutes without activity before the thread is archived: 60, 1440, 4320
+	// or 10080 (default 1440)
+	AutoArchiveMinutes int `yaml:"auto_archive_minutes"`
+}
+
+// Default answer thread settings
+const (
+	DefaultAnswerThreadName        = "Svar: {question}"
+	DefaultAnswerThreadAutoArchive = 1440
+)
+
+// maxThreadNameLength is Discord's limit on thread names
+const maxThreadNameLength = 100
+
+// Name returns the thread name for a question posted at now, cut to fit
+// Discord's limit
+func (a AnswerThreads) Name(question string, now time.Time) string {
+	name := strings.NewReplacer(
+		"{question}", strings.Join(strings.Fields(question), " "),
+		"{date}", now.Format("02.01.2006"),
+	).Replace(orDefault(a.NameTemplate, DefaultAnswerThreadName))
+	if utf8.RuneCountInString(name) > maxThreadNameLength {
+		name = string([]rune(name)[:maxThreadNameLength-1]) + "…"
+	}
+	return name
+}
+
+// AutoArchiveDuration returns the auto-archive duration in minutes. Discord
+// only accepts a few durations, so any other value gives the default.
+func (a AnswerThreads) AutoArchiveDuration() int {
+	switch a.AutoArchiveMinutes {
+	case 60, 1440, 4320, 10080:
+		return a.AutoArchiveMinutes
+	default:
+		return DefaultAnswerThreadAutoArchive
+	}
+}
+
+// ForumTags names the tags in the grammar forum that show the status of a
+// banned word. The tags must exist in the forum; empty names give the defaults.
+type ForumTags struct {
+	Pending  string `yaml:"pending"`  // Waiting for approval, or a proposal to lift the ban (default "ventar")
+	Approved string `yaml:"approved"` // Banned (default "godkjent")
+	Rejected string `yaml:"rejected"` // Report rejected (default "avvist")
+	Unbanned string `yaml:"unbanned"` // Ban lifted (default "oppheva")
+}
+
+// Default forum tag names
+const (
+	DefaultPendingTag  = "ventar"
+	DefaultApprovedTag = "godkjent"
+	DefaultRejectedTag = "avvist"
+	DefaultUnbannedTag = "oppheva"
+)
+
+// PendingTag is the name of the tag for words waiting for a decision
+func (t ForumTags) PendingTag() string {
+	return orDefault(t.Pending, DefaultPendingTag)
+}
+
+// ApprovedTag is the name of the tag for banned words
+func (t ForumTags) ApprovedTag() string {
+	return orDefault(t.Approved, DefaultApprovedTag)
+}
+
+// RejectedTag is the name of the tag for rejected reports
+func (t ForumTags) RejectedTag() string {
+	return orDefault(t.Rejected, DefaultRejectedTag)
+}
+
+// UnbannedTag is the name of the tag for words whose ban was lifted
+func (t ForumTags) UnbannedTag() string {
+	return orDefault(t.Unbanned, DefaultUnbannedTag)
+}
+
+// StatusTags returns the names of all status tags
+func (t ForumTags) StatusTags() []string {
+	return []string{t.PendingTag(), t.ApprovedTag(), t.RejectedTag(), t.UnbannedTag()}
+}
+
+// orDefault returns s, or def if s is empty
+func orDefault(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
+
+// contains reports whether list contains s
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
 // FUNKSJON. Lastar inn konfigurasjonen og gir ein fylt Config-struct
 // --------------------------------------------------------------------------------
 func Load() (*Config, error) {
@@ -0,0 +1,67 @@
+package database
+
+// Categories of banned words. They decide the wording of the warning.
+const (
+	CategoryBokmal    = "bokmal"       // Bokmålsform (default)
+	CategoryAnglicism = "anglisisme"   // Anglisisme
+	CategoryCompound  = "saerskriving" // Særskriving of a compound word
+	CategorySidemal   = "sidemal"      // Sidemålsform that is allowed in some contexts
+)
+
+// Severities of banned words. They decide the tone and colour of the warning.
+const (
+	SeverityLow    = "low"    // A gentle hint
+	SeverityMedium = "medium" // A grammar note (default)
+	SeverityHigh   = "high"   // A proper warning
+)
+
+// BannedWordCategories lists the categories in the order they are shown
+var BannedWordCategories = []string{CategoryBokmal, CategoryAnglicism, CategoryCompound, CategorySidemal}
+
+// DefaultSeverity returns the severity a word gets when only its category is set.
+// Sidemål forms are allowed in some contexts, so they only get a hint.
+func DefaultSeverity(category string) string {
+	if category == CategorySidemal {
+		return SeverityLow
+	}
+	return SeverityMedium
+}
+
+// severityRank orders the severities from mildest to strictest
+var severityRank = map[string]int{SeverityLow: 0, SeverityMedium: 1, SeverityHigh: 2}
+
+// IsValidCategory reports whether category is a known category
+func IsValidCategory(category string) bool {
+	for _, c := range BannedWordCategories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidSeverity reports whether severity is a known severity
+func IsValidSeverity(severity string) bool {
+	_, ok := severityRank[severity]
+	return ok
+}
+
+// HighestSeverity returns the strictest severity among the words, which sets
+// the tone of a warning about all of them. Words without a known severity
+// count as SeverityMedium.
+func HighestSeverity(words []*BannedWord) string {
+	highest := ""
+	for _, bw := range words {
+		severity := bw.Severity
+		if !IsValidSeverity(severity) {
+			severity = SeverityMedium
+		}
+		if highest == "" || severityRank[severity] > severityRank[highest] {
+			highest = severity
+		}
+	}
+	if highest == "" {
+		return SeverityMedium
+	}
+	return highest
+}
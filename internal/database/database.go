@@ -1,6 +1,7 @@
 // Package database implementerer database-operasjonar for Askeladden.
-// Denne pakken handsamar alle spøringar og transaksjonar mot MySQL-databasen,
-// inkludert spørsmål, bannlyste ord og starboard-funksjonalitet.
+// Denne pakken handsamar alle spøringar og transaksjonar mot MySQL-databasen
+// (eller ein lokal SQLite-fil under utvikling), inkludert spørsmål,
+// bannlyste ord og starboard-funksjonalitet.
 package database
 
 import (
@@ -12,10 +13,13 @@ import (
 
 	"github.com/bwmarrin/discordgo"
 	_ "github.com/go-sql-driver/mysql"
+	_ "modernc.org/sqlite"
 
 	"askeladden/internal/config"
 )
 
+// DatabaseIface is the storage contract used by the bot. The MySQL/SQLite DB
+// type implements it, and databasetest provides an in-memory fake for tests.
 type DatabaseIface interface {
 	AddQuestion(question, authorID, authorName, messageID, channelID string) (int64, error)
 	GetQuestionByMessageID(messageID string) (*Question, error)
@@ -26,22 +30,61 @@ type DatabaseIface interface {
 	GetQuestionByApprovalMessageID(approvalMessageID string) (*Question, error)
 	GetPendingQuestionByID(questionID int) (*Question, error)
 	GetApprovalStats() (int, int, int, error)
-	GetLeastAskedApprovedQuestion() (*Question, error)
+	GetApprovedQuestions() ([]*Question, error)
 	IncrementQuestionUsage(questionID int) error
 	GetApprovedQuestionStats() (int, int, int, error)
+	// Question post history methods
+	AddQuestionPost(questionID int, messageID, threadID, channelID, trigger, triggeredBy string) (int64, error)
+	GetQuestionPosts(offset, limit int) ([]*QuestionPost, error)
+	CountQuestionPosts() (int, error)
 	AddBannedWord(word, reason, authorID string) error
 	AddBannedWordPending(word, reason, authorID, authorName, forumThreadID, originalMessageID string) (int64, error)
 	UpdateBannedWordApprovalMessageID(wordID int, approvalMessageID string) error
 	GetBannedWordByApprovalMessageID(approvalMessageID string) (*BannedWord, error)
 	ApproveBannedWordByOpplysar(wordID int, approverID string) error
 	ApproveBannedWordByRettskrivar(wordID int, approverID string) error
+	ApproveBannedWordCombined(wordID int, opplysarApprovers, rettskrivarApprovers []string) error
+	UpdateBannedWordForumThreadID(wordID int, forumThreadID string) error
+	UpdateBannedWordPatterns(wordID int, patterns []string) error
+	UpdateBannedWordSuggestions(wordID int, suggestions []string) error
+	UpdateBannedWordCategory(wordID int, category, severity string) error
+	UpdateBannedWordExceptions(wordID int, exceptions []string) error
+	ImportBannedWord(word, reason string, suggestions []string, importerID, importerName string) error
 	RejectBannedWord(wordID int, rejectorID string) error
+	UpdateBannedWordRejectionReason(wordID int, reason string) error
+	ReopenBannedWord(wordID int, reason, reporterID, reporterName, originalMessageID string) error
 	GetPendingBannedWord() (*BannedWord, error)
 	GetBannedWordByID(wordID int) (*BannedWord, error)
 	GetBannedWordApprovalStats() (int, int, int, int, error)
 	RemoveBannedWord(word string) error
 	IsBannedWord(word string) (bool, *BannedWord, error)
 	GetBannedWords() ([]*BannedWord, error)
+	// Unban proposal methods
+	AddUnbanProposal(wordID int, proposerID, proposerName, reason string) (int64, error)
+	UpdateUnbanProposalApprovalMessageID(proposalID int, approvalMessageID string) error
+	GetUnbanProposalByID(proposalID int) (*UnbanProposal, error)
+	GetUnbanProposalByApprovalMessageID(approvalMessageID string) (*UnbanProposal, error)
+	GetPendingUnbanProposalForWord(wordID int) (*UnbanProposal, error)
+	ApproveUnbanProposal(proposalID int, opplysarApprovers, rettskrivarApprovers []string) error
+	RejectUnbanProposal(proposalID int, rejectorID string) error
+	// Warning methods
+	GetUserWarningMode(userID string) (string, error)
+	SetUserWarningMode(userID, mode string) error
+	AddBannedWordWarning(userID string, wordID int, channelID, sourceMessageID, warningChannelID, warningMessageID string) (int64, error)
+	GetRecentBannedWordWarning(userID string, wordID int, within time.Duration) (*BannedWordWarning, error)
+	HasRecentWarningInChannel(channelID string, within time.Duration) (bool, error)
+	RecordBannedWordWarningHit(warningID int) error
+	GetBannedWordWarningsForMessage(sourceMessageID string) ([]*BannedWordWarning, error)
+	DeleteBannedWordWarning(warningID int) error
+	// Banned word statistics methods
+	AddBannedWordHit(wordID int, userID, channelID, messageID string) error
+	GetBannedWordHitWordIDs(messageID string) ([]int, error)
+	GetTopBannedWords(userID string, since time.Time, limit int) ([]BannedWordHitCount, error)
+	CountBannedWordHits(userID string, from, to time.Time) (int, error)
+	// Allowlist methods
+	GetAllowedPhrases() ([]*AllowedPhrase, error)
+	AddAllowedPhrase(phrase, addedBy string) error
+	RemoveAllowedPhrase(phrase string) (bool, error)
 	// Starboard methods
 	AddStarboardMessage(originalMessageID, starboardMessageID, channelID string) error
 	GetStarboardMessage(originalMessageID string) (string, error)
@@ -55,26 +98,57 @@ type DatabaseIface interface {
 var _ DatabaseIface = (*DB)(nil)
 
 type DB struct {
-	conn             *sql.DB
-	tableName        string // Dynamic table name (daily_questions or daily_questions_testing)
-	bannedWordsTable string // banned_bokmal_words or banned_bokmal_words_testing
-	starboardTable   string // starboard_messages or starboard_messages_testing
+	conn               *sql.DB
+	tableName          string // Dynamic table name (daily_questions or daily_questions_testing)
+	bannedWordsTable   string // banned_bokmal_words or banned_bokmal_words_testing
+	starboardTable     string // starboard_messages or starboard_messages_testing
+	unbanTable         string // banned_word_unban_proposals or banned_word_unban_proposals_testing
+	warningPrefsTable  string // user_warning_preferences or user_warning_preferences_testing
+	warningsTable      string // banned_word_warnings or banned_word_warnings_testing
+	hitsTable          string // banned_word_hits or banned_word_hits_testing
+	allowlistTable     string // banned_word_allowlist or banned_word_allowlist_testing
+	questionPostsTable string // question_posts or question_posts_testing
+	migrationsTable    string // schema_migrations or schema_migrations_testing
+	dialect            dialect
 }
 
-// New creates a new database connection
+// New creates a new database connection and applies pending migrations
 func New(cfg *config.Config) (*DB, error) {
-	log.Printf("Koplar til database på %s:%d", cfg.Database.Host, cfg.Database.Port)
-	connStr := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?parseTime=true",
-		cfg.Database.User, cfg.Database.Password, cfg.Database.Host, cfg.Database.Port, cfg.Database.DBName)
-
-	conn, err := sql.Open("mysql", connStr)
+	db, err := Open(cfg)
 	if err != nil {
-		log.Printf("Kunne ikkje opne database-tilkopling: %v", err)
 		return nil, err
 	}
 
-	if err := conn.Ping(); err != nil {
-		log.Printf("Kunne ikkje teste database-tilkopling: %v", err)
+	if _, err := db.Migrate(); err != nil {
+		log.Printf("Failed to run migrations: %v", err)
+		db.Close()
+		return nil, err
+	}
+
+	log.Println("Database-initialisering fullført")
+	return db, nil
+}
+
+// Open creates a new database connection without touching the schema.
+// The database.driver config key selects MySQL (default) or SQLite.
+func Open(cfg *config.Config) (*DB, error) {
+	var conn *sql.DB
+	var err error
+
+	driver := cfg.Database.Driver
+	if driver == "" {
+		driver = DriverMySQL
+	}
+
+	switch driver {
+	case DriverMySQL:
+		conn, err = openMySQL(cfg)
+	case DriverSQLite:
+		conn, err = openSQLite(cfg)
+	default:
+		return nil, fmt.Errorf("unknown database driver %q (use %s or %s)", driver, DriverMySQL, DriverSQLite)
+	}
+	if err != nil {
 		return nil, err
 	}
 
@@ -83,167 +157,92 @@ func New(cfg *config.Config) (*DB, error) {
 	// Determine table names based on config
 	tableName := "daily_questions"
 	bannedWordsTable := "banned_bokmal_words"
-
 	starboardTable := "starboard_messages"
+	unbanTable := "banned_word_unban_proposals"
+	warningPrefsTable := "user_warning_preferences"
+	warningsTable := "banned_word_warnings"
+	hitsTable := "banned_word_hits"
+	allowlistTable := "banned_word_allowlist"
+	questionPostsTable := "question_posts"
+	migrationsTable := "schema_migrations"
 
 	if cfg.TableSuffix != "" {
 		tableName += cfg.TableSuffix
 		bannedWordsTable += cfg.TableSuffix
 		starboardTable += cfg.TableSuffix
+		unbanTable += cfg.TableSuffix
+		warningPrefsTable += cfg.TableSuffix
+		warningsTable += cfg.TableSuffix
+		hitsTable += cfg.TableSuffix
+		allowlistTable += cfg.TableSuffix
+		questionPostsTable += cfg.TableSuffix
+		migrationsTable += cfg.TableSuffix
 		log.Printf("Brukar beta-tabellnamn: %s, %s, %s", tableName, bannedWordsTable, starboardTable)
 	}
 
-	db := &DB{
-		conn:             conn,
-		tableName:        tableName,
-		bannedWordsTable: bannedWordsTable,
-		starboardTable:   starboardTable,
-	}
+	return &DB{
+		conn:               conn,
+		tableName:          tableName,
+		bannedWordsTable:   bannedWordsTable,
+		starboardTable:     starboardTable,
+		unbanTable:         unbanTable,
+		warningPrefsTable:  warningPrefsTable,
+		warningsTable:      warningsTable,
+		hitsTable:          hitsTable,
+		allowlistTable:     allowlistTable,
+		questionPostsTable: questionPostsTable,
+		migrationsTable:    migrationsTable,
+		dialect:            dialect{name: driver},
+	}, nil
+}
 
-	// Create tables if they don't exist
-	log.Println("Lagar database-tabellar viss dei ikkje finst")
-	if err := db.createTables(); err != nil {
-		log.Printf("Kunne ikkje lage tabellar: %v", err)
-		return nil, err
-	}
+// openMySQL opens and pings the remote MySQL database from the config
+func openMySQL(cfg *config.Config) (*sql.DB, error) {
+	log.Printf("Koplar til database på %s:%d", cfg.Database.Host, cfg.Database.Port)
+	// clientFoundRows makes RowsAffected count matched rows like SQLite does, so
+	// an UPDATE that sets a column to its current value isn't taken for a miss
+	connStr := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?parseTime=true&clientFoundRows=true",
+		cfg.Database.User, cfg.Database.Password, cfg.Database.Host, cfg.Database.Port, cfg.Database.DBName)
 
-	// Create banned Bokmål words table
-	bannedWordsQuery := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
-		id INT AUTO_INCREMENT PRIMARY KEY,
-		word VARCHAR(255) NOT NULL UNIQUE,
-		reason TEXT,
-		author_id VARCHAR(255) NOT NULL,
-		author_name VARCHAR(255) NOT NULL,
-		forum_thread_id VARCHAR(255),
-		original_message_id VARCHAR(255),
-		approval_status ENUM('pending', 'opplysar_approved', 'fully_approved', 'rejected') DEFAULT 'pending',
-		approval_message_id VARCHAR(255),
-		opplysar_approved_by VARCHAR(255),
-		opplysar_approved_at TIMESTAMP NULL,
-		rettskrivar_approved_by VARCHAR(255),
-		rettskrivar_approved_at TIMESTAMP NULL,
-		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
-	);`, db.bannedWordsTable)
-
-	log.Printf("Lagar tabell: %s", db.bannedWordsTable)
-	if _, err := db.conn.Exec(bannedWordsQuery); err != nil {
-		return nil, fmt.Errorf("failed to create %s table: %w", db.bannedWordsTable, err)
-	}
-
-	// Run migrations
-	if err := db.runMigrations(); err != nil {
-		log.Printf("Failed to run migrations: %v", err)
+	conn, err := sql.Open("mysql", connStr)
+	if err != nil {
+		log.Printf("Kunne ikkje opne database-tilkopling: %v", err)
 		return nil, err
 	}
 
-	log.Println("Database-initialisering fullført")
-	return db, nil
-}
-
-// createTables creates the necessary database tables if they don't exist
-func (db *DB) createTables() error {
-	// Create questions table with approval column and usage tracking
-	// Use dynamic table name (daily_questions or daily_questions_testing)
-	questionsQuery := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
-		id INT AUTO_INCREMENT PRIMARY KEY,
-		question TEXT NOT NULL,
-		author_id VARCHAR(255) NOT NULL,
-		author_name VARCHAR(255) NOT NULL,
-		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-		times_asked INT DEFAULT 0,
-		last_asked_at TIMESTAMP NULL,
-		message_id VARCHAR(255),
-		channel_id VARCHAR(255),
-		approval_status ENUM('pending', 'approved', 'rejected') DEFAULT 'pending',
-		approval_message_id VARCHAR(255),
-		approved_by VARCHAR(255),
-		approved_at TIMESTAMP NULL
-	);`, db.tableName)
-
-	log.Printf("Creating table if not exists: %s", db.tableName)
-	if _, err := db.conn.Exec(questionsQuery); err != nil {
-		return fmt.Errorf("failed to create %s table: %w", db.tableName, err)
-	}
-
-	// Create starboard messages table
-	starboardQuery := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
-		id INT AUTO_INCREMENT PRIMARY KEY,
-		original_message_id VARCHAR(255) NOT NULL UNIQUE,
-		starboard_message_id VARCHAR(255) NOT NULL,
-		channel_id VARCHAR(255) NOT NULL,
-		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
-	);`, db.starboardTable)
-
-	log.Printf("Creating table if not exists: %s", db.starboardTable)
-	if _, err := db.conn.Exec(starboardQuery); err != nil {
-		return fmt.Errorf("failed to create %s table: %w", db.starboardTable, err)
+	if err := conn.Ping(); err != nil {
+		log.Printf("Kunne ikkje teste database-tilkopling: %v", err)
+		conn.Close()
+		return nil, err
 	}
-
-	return nil
+	return conn, nil
 }
 
-// resetTables creates the necessary database tables (ONLY FOR TESTING - DROPS EXISTING DATA)
-func (db *DB) resetTables() error {
-	// Drop tables if they exist
-	log.Println("Dropping existing tables if any")
-	_, err := db.conn.Exec(fmt.Sprintf("DROP TABLE IF EXISTS %s, %s", db.tableName, db.bannedWordsTable))
-	if err != nil {
-		return fmt.Errorf("failed to drop tables: %w", err)
-	}
-	// Create questions table with approval column and usage tracking
-	// Use dynamic table name (daily_questions or daily_questions_testing)
-	questionsQuery := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
-		id INT AUTO_INCREMENT PRIMARY KEY,
-		question TEXT NOT NULL,
-		author_id VARCHAR(255) NOT NULL,
-		author_name VARCHAR(255) NOT NULL,
-		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-		times_asked INT DEFAULT 0,
-		last_asked_at TIMESTAMP NULL,
-		message_id VARCHAR(255),
-		channel_id VARCHAR(255),
-		approval_status ENUM('pending', 'approved', 'rejected') DEFAULT 'pending',
-		approval_message_id VARCHAR(255),
-		approved_by VARCHAR(255),
-		approved_at TIMESTAMP NULL
-	);`, db.tableName)
-
-	log.Printf("Creating table: %s", db.tableName)
-	if _, err := db.conn.Exec(questionsQuery); err != nil {
-		return fmt.Errorf("failed to create %s table: %w", db.tableName, err)
+// openSQLite opens (and creates if needed) the embedded SQLite database file.
+// The path ":memory:" gives a throwaway database, which is handy for tests.
+func openSQLite(cfg *config.Config) (*sql.DB, error) {
+	path := cfg.Database.Path
+	if path == "" {
+		path = "askeladden.db"
 	}
+	log.Printf("Opnar SQLite-database: %s", path)
 
-	return nil
-}
-
-// runMigrations runs database migrations to update existing tables
-func (db *DB) runMigrations() error {
-	log.Println("Running database migrations")
-
-	// Migration 1: Add forum_thread_id column to banned words table if it doesn't exist
-	columnCheckQuery := fmt.Sprintf("SELECT COUNT(*) FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = '%s' AND column_name = 'forum_thread_id'", db.bannedWordsTable)
-	var columnExists int
-	err := db.conn.QueryRow(columnCheckQuery).Scan(&columnExists)
+	conn, err := sql.Open("sqlite", path+"?_pragma=busy_timeout(5000)")
 	if err != nil {
-		log.Printf("Failed to check if forum_thread_id column exists: %v", err)
-		return err
+		log.Printf("Kunne ikkje opne SQLite-database: %v", err)
+		return nil, err
 	}
 
-	if columnExists == 0 {
-		log.Printf("Adding forum_thread_id column to %s table", db.bannedWordsTable)
-		alterQuery := fmt.Sprintf("ALTER TABLE %s ADD COLUMN forum_thread_id VARCHAR(255) NULL", db.bannedWordsTable)
-		_, err = db.conn.Exec(alterQuery)
-		if err != nil {
-			log.Printf("Failed to add forum_thread_id column: %v", err)
-			return err
-		}
-		log.Printf("Successfully added forum_thread_id column to %s", db.bannedWordsTable)
-	} else {
-		log.Printf("forum_thread_id column already exists in %s", db.bannedWordsTable)
-	}
+	// SQLite handles one writer at a time, and an in-memory database only
+	// lives as long as its connection, so keep everything on one connection.
+	conn.SetMaxOpenConns(1)
 
-	log.Println("Database migrations completed")
-	return nil
+	if err := conn.Ping(); err != nil {
+		log.Printf("Kunne ikkje teste SQLite-database: %v", err)
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
 }
 
 // Question represents a question from the database
@@ -279,6 +278,54 @@ type BannedWord struct {
 	RettskrivarApprovedAt *time.Time
 	CreatedAt             time.Time
 	OriginalMessageID     *string
+	Patterns              []string // Extra phrases, inflections and stem* patterns
+	Suggestions           []string // Correct nynorsk alternatives shown in warnings
+	RejectedBy            *string  // Rejector, or the reporter if the report was withdrawn
+	RejectedAt            *time.Time
+	RejectionReason       *string
+	UnbannedAt            *time.Time // Set when an approved unban proposal lifted the ban
+	Category              string     // One of the Category constants
+	Severity              string     // One of the Severity constants
+	Exceptions            []string   // Phrases and /regexes/ where the word is allowed
+}
+
+// IsActive reports whether the word is approved and its ban has not been lifted
+func (bw *BannedWord) IsActive() bool {
+	return bw.ApprovalStatus == "fully_approved" && bw.UnbannedAt == nil
+}
+
+// bannedWordColumns is the column list scanned by scanBannedWord
+const bannedWordColumns = "id, word, reason, author_id, author_name, forum_thread_id, approval_status, approval_message_id, opplysar_approved_by, opplysar_approved_at, rettskrivar_approved_by, rettskrivar_approved_at, created_at, original_message_id, patterns, suggestions, rejected_by, rejected_at, rejection_reason, unbanned_at, category, severity, exceptions"
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanBannedWord scans a row selected with bannedWordColumns
+func scanBannedWord(row rowScanner) (*BannedWord, error) {
+	var bw BannedWord
+	var patterns, suggestions, exceptions sql.NullString
+	err := row.Scan(
+		&bw.ID, &bw.Word, &bw.Reason, &bw.AuthorID, &bw.AuthorName, &bw.ForumThreadID,
+		&bw.ApprovalStatus, &bw.ApprovalMessageID, &bw.OpplysarApprovedBy, &bw.OpplysarApprovedAt,
+		&bw.RettskrivarApprovedBy, &bw.RettskrivarApprovedAt, &bw.CreatedAt, &bw.OriginalMessageID,
+		&patterns, &suggestions, &bw.RejectedBy, &bw.RejectedAt, &bw.RejectionReason,
+		&bw.UnbannedAt, &bw.Category, &bw.Severity, &exceptions,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if patterns.String != "" {
+		bw.Patterns = strings.Split(patterns.String, "\n")
+	}
+	if suggestions.String != "" {
+		bw.Suggestions = strings.Split(suggestions.String, "\n")
+	}
+	if exceptions.String != "" {
+		bw.Exceptions = strings.Split(exceptions.String, "\n")
+	}
+	return &bw, nil
 }
 
 // StarboardMessage represents a starboard message mapping from the database
@@ -330,7 +377,7 @@ func (db *DB) GetQuestionByMessageID(messageID string) (*Question, error) {
 // ApproveQuestion updates the approval status for a question
 func (db *DB) ApproveQuestion(questionID int, approverID string) error {
 	log.Printf("Approving question ID %d by approver %s", questionID, approverID)
-	query := fmt.Sprintf("UPDATE %s SET approval_status = 'approved', approved_by = ?, approved_at = NOW() WHERE id = ?", db.tableName)
+	query := fmt.Sprintf("UPDATE %s SET approval_status = 'approved', approved_by = ?, approved_at = CURRENT_TIMESTAMP WHERE id = ?", db.tableName)
 	_, err := db.conn.Exec(query, approverID, questionID)
 	if err != nil {
 		log.Printf("Failed to approve question ID %d: %v", questionID, err)
@@ -343,7 +390,7 @@ func (db *DB) ApproveQuestion(questionID int, approverID string) error {
 // RejectQuestion updates the approval status for a question to rejected
 func (db *DB) RejectQuestion(questionID int, rejectorID string) error {
 	log.Printf("Rejecting question ID %d by rejector %s", questionID, rejectorID)
-	query := fmt.Sprintf("UPDATE %s SET approval_status = 'rejected', approved_by = ?, approved_at = NOW() WHERE id = ?", db.tableName)
+	query := fmt.Sprintf("UPDATE %s SET approval_status = 'rejected', approved_by = ?, approved_at = CURRENT_TIMESTAMP WHERE id = ?", db.tableName)
 	_, err := db.conn.Exec(query, rejectorID, questionID)
 	if err != nil {
 		log.Printf("Failed to reject question ID %d: %v", questionID, err)
@@ -455,32 +502,35 @@ func (db *DB) GetApprovalStats() (int, int, int, error) {
 	return pending, approved, rejected, nil
 }
 
-// GetLeastAskedApprovedQuestion gets the least asked approved question for equal distribution
-func (db *DB) GetLeastAskedApprovedQuestion() (*Question, error) {
-	log.Println("Retrieving least asked approved question")
-	query := fmt.Sprintf("SELECT id, question, author_id, author_name, created_at, times_asked, last_asked_at, message_id, channel_id, approval_status, approval_message_id, approved_by, approved_at FROM %s WHERE approval_status = 'approved' ORDER BY times_asked ASC, created_at ASC LIMIT 1", db.tableName)
-	var q Question
-	err := db.conn.QueryRow(query).Scan(
-		&q.ID, &q.Question, &q.AuthorID, &q.AuthorName, &q.CreatedAt, &q.TimesAsked, &q.LastAskedAt,
-		&q.MessageID, &q.ChannelID, &q.ApprovalStatus, &q.ApprovalMessageID, &q.ApprovedBy, &q.ApprovedAt,
-	)
+// GetApprovedQuestions returns every approved question, oldest first
+func (db *DB) GetApprovedQuestions() ([]*Question, error) {
+	query := fmt.Sprintf("SELECT id, question, author_id, author_name, created_at, times_asked, last_asked_at, message_id, channel_id, approval_status, approval_message_id, approved_by, approved_at FROM %s WHERE approval_status = 'approved' ORDER BY created_at ASC, id ASC", db.tableName)
+	rows, err := db.conn.Query(query)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			log.Println("[DATABASE] No approved questions found")
-			return nil, nil
-		} else {
-			log.Printf("[DATABASE] Failed to get least asked approved question: %v", err)
+		log.Printf("[DATABASE] Failed to get approved questions: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var questions []*Question
+	for rows.Next() {
+		var q Question
+		err := rows.Scan(
+			&q.ID, &q.Question, &q.AuthorID, &q.AuthorName, &q.CreatedAt, &q.TimesAsked, &q.LastAskedAt,
+			&q.MessageID, &q.ChannelID, &q.ApprovalStatus, &q.ApprovalMessageID, &q.ApprovedBy, &q.ApprovedAt,
+		)
+		if err != nil {
 			return nil, err
 		}
+		questions = append(questions, &q)
 	}
-	log.Printf("[DATABASE] Retrieved least asked approved question (asked %d times): %s", q.TimesAsked, q.Question)
-	return &q, nil
+	return questions, rows.Err()
 }
 
 // IncrementQuestionUsage increments the times_asked count and updates last_asked_at for a question
 func (db *DB) IncrementQuestionUsage(questionID int) error {
 	log.Printf("[DATABASE] Incrementing usage count for question ID %d", questionID)
-	query := fmt.Sprintf("UPDATE %s SET times_asked = times_asked + 1, last_asked_at = NOW() WHERE id = ?", db.tableName)
+	query := fmt.Sprintf("UPDATE %s SET times_asked = times_asked + 1, last_asked_at = CURRENT_TIMESTAMP WHERE id = ?", db.tableName)
 	_, err := db.conn.Exec(query, questionID)
 	if err != nil {
 		log.Printf("[DATABASE] Failed to increment usage count for question ID %d: %v", questionID, err)
@@ -570,13 +620,8 @@ func (db *DB) UpdateBannedWordApprovalMessageID(wordID int, approvalMessageID st
 // GetBannedWordByApprovalMessageID gets a banned word by its approval message ID
 func (db *DB) GetBannedWordByApprovalMessageID(approvalMessageID string) (*BannedWord, error) {
 	log.Printf("Looking up banned word by approval message ID: %s", approvalMessageID)
-	query := fmt.Sprintf("SELECT id, word, reason, author_id, author_name, forum_thread_id, approval_status, approval_message_id, opplysar_approved_by, opplysar_approved_at, rettskrivar_approved_by, rettskrivar_approved_at, created_at, original_message_id FROM %s WHERE approval_message_id = ?", db.bannedWordsTable)
-	var bw BannedWord
-	err := db.conn.QueryRow(query, approvalMessageID).Scan(
-		&bw.ID, &bw.Word, &bw.Reason, &bw.AuthorID, &bw.AuthorName, &bw.ForumThreadID,
-		&bw.ApprovalStatus, &bw.ApprovalMessageID, &bw.OpplysarApprovedBy, &bw.OpplysarApprovedAt,
-		&bw.RettskrivarApprovedBy, &bw.RettskrivarApprovedAt, &bw.CreatedAt, &bw.OriginalMessageID,
-	)
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE approval_message_id = ?", bannedWordColumns, db.bannedWordsTable)
+	bw, err := scanBannedWord(db.conn.QueryRow(query, approvalMessageID))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			log.Printf("No banned word found for approval message ID: %s", approvalMessageID)
@@ -586,13 +631,13 @@ func (db *DB) GetBannedWordByApprovalMessageID(approvalMessageID string) (*Banne
 		return nil, err
 	}
 	log.Printf("Found banned word ID %d for approval message %s", bw.ID, approvalMessageID)
-	return &bw, nil
+	return bw, nil
 }
 
 // ApproveBannedWordByOpplysar approves a banned word by opplysar
 func (db *DB) ApproveBannedWordByOpplysar(wordID int, approverID string) error {
 	log.Printf("Opplysar approving banned word ID %d by %s", wordID, approverID)
-	query := fmt.Sprintf("UPDATE %s SET approval_status = 'opplysar_approved', opplysar_approved_by = ?, opplysar_approved_at = NOW() WHERE id = ? AND approval_status = 'pending'", db.bannedWordsTable)
+	query := fmt.Sprintf("UPDATE %s SET approval_status = 'opplysar_approved', opplysar_approved_by = ?, opplysar_approved_at = CURRENT_TIMESTAMP WHERE id = ? AND approval_status = 'pending'", db.bannedWordsTable)
 	result, err := db.conn.Exec(query, approverID, wordID)
 	if err != nil {
 		log.Printf("Failed to approve banned word by opplysar ID %d: %v", wordID, err)
@@ -613,7 +658,7 @@ func (db *DB) ApproveBannedWordByOpplysar(wordID int, approverID string) error {
 // ApproveBannedWordByRettskrivar approves a banned word by rettskrivar
 func (db *DB) ApproveBannedWordByRettskrivar(wordID int, approverID string) error {
 	log.Printf("Rettskrivar approving banned word ID %d by %s", wordID, approverID)
-	query := fmt.Sprintf("UPDATE %s SET approval_status = 'fully_approved', rettskrivar_approved_by = ?, rettskrivar_approved_at = NOW() WHERE id = ? AND approval_status = 'opplysar_approved'", db.bannedWordsTable)
+	query := fmt.Sprintf("UPDATE %s SET approval_status = 'fully_approved', rettskrivar_approved_by = ?, rettskrivar_approved_at = CURRENT_TIMESTAMP WHERE id = ? AND approval_status = 'opplysar_approved'", db.bannedWordsTable)
 	result, err := db.conn.Exec(query, approverID, wordID)
 	if err != nil {
 		log.Printf("Failed to approve banned word by rettskrivar ID %d: %v", wordID, err)
@@ -639,7 +684,7 @@ func (db *DB) ApproveBannedWordCombined(wordID int, opplysarApprovers, rettskriv
 	opplysarList := strings.Join(opplysarApprovers, ",")
 	rettskrivarList := strings.Join(rettskrivarApprovers, ",")
 
-	query := fmt.Sprintf("UPDATE %s SET approval_status = 'fully_approved', opplysar_approved_by = ?, rettskrivar_approved_by = ?, opplysar_approved_at = NOW(), rettskrivar_approved_at = NOW() WHERE id = ? AND approval_status = 'pending'", db.bannedWordsTable)
+	query := fmt.Sprintf("UPDATE %s SET approval_status = 'fully_approved', opplysar_approved_by = ?, rettskrivar_approved_by = ?, opplysar_approved_at = CURRENT_TIMESTAMP, rettskrivar_approved_at = CURRENT_TIMESTAMP WHERE id = ? AND approval_status = 'pending'", db.bannedWordsTable)
 	result, err := db.conn.Exec(query, opplysarList, rettskrivarList, wordID)
 	if err != nil {
 		log.Printf("Failed to approve banned word with combined approval ID %d: %v", wordID, err)
@@ -670,10 +715,104 @@ func (db *DB) UpdateBannedWordForumThreadID(wordID int, forumThreadID string) er
 	return nil
 }
 
-// RejectBannedWord updates the approval status for a banned word to rejected
+// UpdateBannedWordPatterns replaces the extra match patterns for a banned word.
+// Patterns are stored newline-separated, and an empty list clears them.
+func (db *DB) UpdateBannedWordPatterns(wordID int, patterns []string) error {
+	log.Printf("Updating patterns for banned word %d: %v", wordID, patterns)
+	var value any
+	if len(patterns) > 0 {
+		value = strings.Join(patterns, "\n")
+	}
+	query := fmt.Sprintf("UPDATE %s SET patterns = ? WHERE id = ?", db.bannedWordsTable)
+	result, err := db.conn.Exec(query, value, wordID)
+	if err != nil {
+		log.Printf("Failed to update patterns for banned word %d: %v", wordID, err)
+		return err
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("no banned word found with ID %d", wordID)
+	}
+	log.Printf("Successfully updated patterns for banned word %d", wordID)
+	return nil
+}
+
+// UpdateBannedWordSuggestions replaces the suggested alternatives for a banned word.
+// Suggestions are stored newline-separated, and an empty list clears them.
+func (db *DB) UpdateBannedWordSuggestions(wordID int, suggestions []string) error {
+	log.Printf("Updating suggestions for banned word %d: %v", wordID, suggestions)
+	var value any
+	if len(suggestions) > 0 {
+		value = strings.Join(suggestions, "\n")
+	}
+	query := fmt.Sprintf("UPDATE %s SET suggestions = ? WHERE id = ?", db.bannedWordsTable)
+	result, err := db.conn.Exec(query, value, wordID)
+	if err != nil {
+		log.Printf("Failed to update suggestions for banned word %d: %v", wordID, err)
+		return err
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("no banned word found with ID %d", wordID)
+	}
+	log.Printf("Successfully updated suggestions for banned word %d", wordID)
+	return nil
+}
+
+// UpdateBannedWordCategory sets the category and severity of a banned word
+func (db *DB) UpdateBannedWordCategory(wordID int, category, severity string) error {
+	log.Printf("Updating category for banned word %d: %s (%s)", wordID, category, severity)
+	query := fmt.Sprintf("UPDATE %s SET category = ?, severity = ? WHERE id = ?", db.bannedWordsTable)
+	result, err := db.conn.Exec(query, category, severity, wordID)
+	if err != nil {
+		log.Printf("Failed to update category for banned word %d: %v", wordID, err)
+		return err
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("no banned word found with ID %d", wordID)
+	}
+	return nil
+}
+
+// ImportBannedWord adds or updates a fully approved banned word from a word list.
+// The importer is recorded as both opplysar and rettskrivar approver, and as
+// author of new words. Words that are already approved keep their approvers,
+// and a lifted ban is put back in place.
+func (db *DB) ImportBannedWord(word, reason string, suggestions []string, importerID, importerName string) error {
+	log.Printf("Importing banned word: %s by %s", word, importerID)
+	var suggestionsValue any
+	if len(suggestions) > 0 {
+		suggestionsValue = strings.Join(suggestions, "\n")
+	}
+
+	isBanned, existing, err := db.IsBannedWord(word)
+	if err != nil {
+		log.Printf("Failed to look up banned word for import: %v", err)
+		return err
+	}
+
+	switch {
+	case !isBanned:
+		query := fmt.Sprintf("INSERT INTO %s (word, reason, author_id, author_name, approval_status, opplysar_approved_by, opplysar_approved_at, rettskrivar_approved_by, rettskrivar_approved_at, suggestions) VALUES (?, ?, ?, ?, 'fully_approved', ?, CURRENT_TIMESTAMP, ?, CURRENT_TIMESTAMP, ?)", db.bannedWordsTable)
+		_, err = db.conn.Exec(query, word, reason, importerID, importerName, importerID, importerID, suggestionsValue)
+	case existing.ApprovalStatus == "fully_approved":
+		query := fmt.Sprintf("UPDATE %s SET reason = ?, suggestions = ?, unbanned_at = NULL WHERE id = ?", db.bannedWordsTable)
+		_, err = db.conn.Exec(query, reason, suggestionsValue, existing.ID)
+	default:
+		query := fmt.Sprintf("UPDATE %s SET reason = ?, suggestions = ?, approval_status = 'fully_approved', opplysar_approved_by = ?, opplysar_approved_at = CURRENT_TIMESTAMP, rettskrivar_approved_by = ?, rettskrivar_approved_at = CURRENT_TIMESTAMP, unbanned_at = NULL WHERE id = ?", db.bannedWordsTable)
+		_, err = db.conn.Exec(query, reason, suggestionsValue, importerID, importerID, existing.ID)
+	}
+	if err != nil {
+		log.Printf("Failed to import banned word %s: %v", word, err)
+		return err
+	}
+	log.Printf("Successfully imported banned word: %s", word)
+	return nil
+}
+
+// RejectBannedWord updates the approval status for a banned word to rejected.
+// If the rejector is the reporter, the report counts as withdrawn.
 func (db *DB) RejectBannedWord(wordID int, rejectorID string) error {
 	log.Printf("Rejecting banned word ID %d by rejector %s", wordID, rejectorID)
-	query := fmt.Sprintf("UPDATE %s SET approval_status = 'rejected', opplysar_approved_by = ?, opplysar_approved_at = NOW() WHERE id = ? AND approval_status = 'pending'", db.bannedWordsTable)
+	query := fmt.Sprintf("UPDATE %s SET approval_status = 'rejected', rejected_by = ?, rejected_at = CURRENT_TIMESTAMP WHERE id = ? AND approval_status = 'pending'", db.bannedWordsTable)
 	result, err := db.conn.Exec(query, rejectorID, wordID)
 	if err != nil {
 		log.Printf("Failed to reject banned word ID %d: %v", wordID, err)
@@ -691,15 +830,50 @@ func (db *DB) RejectBannedWord(wordID int, rejectorID string) error {
 	return nil
 }
 
+// UpdateBannedWordRejectionReason sets the reason given for a rejected banned word
+func (db *DB) UpdateBannedWordRejectionReason(wordID int, reason string) error {
+	log.Printf("Updating rejection reason for banned word %d", wordID)
+	query := fmt.Sprintf("UPDATE %s SET rejection_reason = ? WHERE id = ? AND approval_status = 'rejected'", db.bannedWordsTable)
+	result, err := db.conn.Exec(query, reason, wordID)
+	if err != nil {
+		log.Printf("Failed to update rejection reason for banned word %d: %v", wordID, err)
+		return err
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("no rejected banned word found with ID %d", wordID)
+	}
+	log.Printf("Successfully updated rejection reason for banned word %d", wordID)
+	return nil
+}
+
+// ReopenBannedWord puts a rejected or unbanned word back up for approval as a
+// new report. Earlier approvals and the rejection are cleared, while the forum
+// thread, patterns and suggestions are kept.
+func (db *DB) ReopenBannedWord(wordID int, reason, reporterID, reporterName, originalMessageID string) error {
+	log.Printf("Reopening banned word ID %d reported by %s", wordID, reporterID)
+	query := fmt.Sprintf(`UPDATE %s SET approval_status = 'pending', reason = ?, author_id = ?, author_name = ?, original_message_id = ?,
+		approval_message_id = NULL, opplysar_approved_by = NULL, opplysar_approved_at = NULL, rettskrivar_approved_by = NULL, rettskrivar_approved_at = NULL,
+		rejected_by = NULL, rejected_at = NULL, rejection_reason = NULL, unbanned_at = NULL
+		WHERE id = ? AND (approval_status = 'rejected' OR unbanned_at IS NOT NULL)`, db.bannedWordsTable)
+	result, err := db.conn.Exec(query, reason, reporterID, reporterName, originalMessageID, wordID)
+	if err != nil {
+		log.Printf("Failed to reopen banned word ID %d: %v", wordID, err)
+		return err
+	}
+
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		log.Printf("No rejected or unbanned word with ID %d found to reopen", wordID)
+		return fmt.Errorf("no rejected or unbanned banned word found to reopen")
+	}
+	return nil
+}
+
 // GetPendingBannedWord retrieves the next pending banned word for approval
 func (db *DB) GetPendingBannedWord() (*BannedWord, error) {
 	log.Println("Retrieving next pending banned word")
-	query := fmt.Sprintf("SELECT id, word, reason, author_id, author_name, forum_thread_id, approval_status, approval_message_id, opplysar_approved_by, opplysar_approved_at, rettskrivar_approved_by, rettskrivar_approved_at, created_at FROM %s WHERE approval_status = 'pending' ORDER BY created_at ASC LIMIT 1", db.bannedWordsTable)
-	var bw BannedWord
-	err := db.conn.QueryRow(query).Scan(&bw.ID, &bw.Word, &bw.Reason, &bw.AuthorID, &bw.AuthorName, &bw.ForumThreadID,
-		&bw.ApprovalStatus, &bw.ApprovalMessageID, &bw.OpplysarApprovedBy, &bw.OpplysarApprovedAt,
-		&bw.RettskrivarApprovedBy, &bw.RettskrivarApprovedAt, &bw.CreatedAt,
-	)
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE approval_status = 'pending' ORDER BY created_at ASC LIMIT 1", bannedWordColumns, db.bannedWordsTable)
+	bw, err := scanBannedWord(db.conn.QueryRow(query))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			log.Println("No pending banned words found")
@@ -709,19 +883,14 @@ func (db *DB) GetPendingBannedWord() (*BannedWord, error) {
 		return nil, err
 	}
 	log.Printf("Retrieved pending banned word ID %d: %s", bw.ID, bw.Word)
-	return &bw, nil
+	return bw, nil
 }
 
 // GetBannedWordByID gets a banned word by its ID
 func (db *DB) GetBannedWordByID(wordID int) (*BannedWord, error) {
 	log.Printf("Looking up banned word by ID: %d", wordID)
-	query := fmt.Sprintf("SELECT id, word, reason, author_id, author_name, forum_thread_id, approval_status, approval_message_id, opplysar_approved_by, opplysar_approved_at, rettskrivar_approved_by, rettskrivar_approved_at, created_at FROM %s WHERE id = ?", db.bannedWordsTable)
-	var bw BannedWord
-	err := db.conn.QueryRow(query, wordID).Scan(
-		&bw.ID, &bw.Word, &bw.Reason, &bw.AuthorID, &bw.AuthorName, &bw.ForumThreadID,
-		&bw.ApprovalStatus, &bw.ApprovalMessageID, &bw.OpplysarApprovedBy, &bw.OpplysarApprovedAt,
-		&bw.RettskrivarApprovedBy, &bw.RettskrivarApprovedAt, &bw.CreatedAt,
-	)
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE id = ?", bannedWordColumns, db.bannedWordsTable)
+	bw, err := scanBannedWord(db.conn.QueryRow(query, wordID))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			log.Printf("No banned word found with ID: %d", wordID)
@@ -731,7 +900,7 @@ func (db *DB) GetBannedWordByID(wordID int) (*BannedWord, error) {
 		return nil, err
 	}
 	log.Printf("Found banned word ID %d: %s", bw.ID, bw.Word)
-	return &bw, nil
+	return bw, nil
 }
 
 // GetBannedWordApprovalStats returns statistics about banned word approvals
@@ -808,23 +977,20 @@ func (db *DB) RemoveBannedWord(word string) error {
 
 // IsBannedWord checks if a word is banned
 func (db *DB) IsBannedWord(word string) (bool, *BannedWord, error) {
-	query := fmt.Sprintf("SELECT id, word, reason, author_id, forum_thread_id, created_at FROM %s WHERE word = ?", db.bannedWordsTable)
-	var bw BannedWord
-	err := db.conn.QueryRow(query, word).Scan(
-		&bw.ID, &bw.Word, &bw.Reason, &bw.AuthorID, &bw.ForumThreadID, &bw.CreatedAt,
-	)
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE word = ?", bannedWordColumns, db.bannedWordsTable)
+	bw, err := scanBannedWord(db.conn.QueryRow(query, word))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return false, nil, nil
 		}
 		return false, nil, err
 	}
-	return true, &bw, nil
+	return true, bw, nil
 }
 
 // GetBannedWords returns all banned words
 func (db *DB) GetBannedWords() ([]*BannedWord, error) {
-	query := fmt.Sprintf("SELECT id, word, reason, author_id, forum_thread_id, created_at FROM %s ORDER BY created_at DESC", db.bannedWordsTable)
+	query := fmt.Sprintf("SELECT %s FROM %s ORDER BY created_at DESC", bannedWordColumns, db.bannedWordsTable)
 	rows, err := db.conn.Query(query)
 	if err != nil {
 		return nil, err
@@ -833,14 +999,13 @@ func (db *DB) GetBannedWords() ([]*BannedWord, error) {
 
 	var words []*BannedWord
 	for rows.Next() {
-		var bw BannedWord
-		err := rows.Scan(&bw.ID, &bw.Word, &bw.Reason, &bw.AuthorID, &bw.ForumThreadID, &bw.CreatedAt)
+		bw, err := scanBannedWord(rows)
 		if err != nil {
 			return nil, err
 		}
-		words = append(words, &bw)
+		words = append(words, bw)
 	}
-	return words, nil
+	return words, rows.Err()
 }
 
 // AddStarboardMessage adds a new starboard message mapping to the database
@@ -896,10 +1061,11 @@ func (db *DB) RemoveStarboardMessage(originalMessageID string) error {
 	return nil
 }
 
-// ClearDatabase drops all tables from the database
+// ClearDatabase deletes all questions from the database. The table itself is
+// kept so that the migrated schema stays intact.
 func (db *DB) ClearDatabase() error {
 	log.Println("Clearing the database")
-	query := fmt.Sprintf("DROP TABLE IF EXISTS %s", db.tableName)
+	query := fmt.Sprintf("DELETE FROM %s", db.tableName)
 	_, err := db.conn.Exec(query)
 	if err != nil {
 		log.Printf("Failed to clear the database: %v", err)
@@ -0,0 +1,265 @@
+package database
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"askeladden/internal/config"
+)
+
+// sqliteConfig returns a config for a SQLite file in a fresh temporary directory
+func sqliteConfig(t *testing.T, suffix string) *config.Config {
+	t.Helper()
+	cfg := &config.Config{TableSuffix: suffix}
+	cfg.Database.Driver = DriverSQLite
+	cfg.Database.Path = filepath.Join(t.TempDir(), "askeladden.db")
+	return cfg
+}
+
+// newTestDB opens the database from cfg with every migration applied
+func newTestDB(t *testing.T, cfg *config.Config) *DB {
+	t.Helper()
+	db, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestNewAppliesEveryMigration(t *testing.T) {
+	db := newTestDB(t, sqliteConfig(t, ""))
+
+	states, err := db.MigrationStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(states) != len(migrations) {
+		t.Fatalf("%d migration states, want %d", len(states), len(migrations))
+	}
+	for _, s := range states {
+		if s.AppliedAt == nil {
+			t.Errorf("migration %d (%s) not applied", s.Version, s.Description)
+		}
+	}
+
+	for _, column := range []string{"patterns", "suggestions", "rejection_reason", "unbanned_at", "category", "exceptions"} {
+		if ok, err := db.columnExists(db.bannedWordsTable, column); err != nil || !ok {
+			t.Errorf("banned words column %s missing: %v", column, err)
+		}
+	}
+}
+
+func TestBannedWordApproval(t *testing.T) {
+	db := newTestDB(t, sqliteConfig(t, ""))
+
+	id, err := db.AddBannedWordPending("ikkje", "bokmål", "melder", "Melder", "traad", "c|m")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wordID := int(id)
+	if err := db.UpdateBannedWordApprovalMessageID(wordID, "bw-msg"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := db.ApproveBannedWordByRettskrivar(wordID, "rett"); err == nil {
+		t.Error("rettskrivar approval before opplysar should fail")
+	}
+	if err := db.ApproveBannedWordByOpplysar(wordID, "opp"); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.ApproveBannedWordByRettskrivar(wordID, "rett"); err != nil {
+		t.Fatal(err)
+	}
+
+	bw, err := db.GetBannedWordByApprovalMessageID("bw-msg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bw.IsActive() || *bw.OpplysarApprovedBy != "opp" || *bw.RettskrivarApprovedBy != "rett" || *bw.ForumThreadID != "traad" {
+		t.Errorf("approved word = %+v", bw)
+	}
+	if err := db.RejectBannedWord(wordID, "opp"); err == nil {
+		t.Error("rejecting an approved word should fail")
+	}
+}
+
+func TestBannedWordRejection(t *testing.T) {
+	db := newTestDB(t, sqliteConfig(t, ""))
+
+	id, _ := db.AddBannedWordPending("ikkje", "", "melder", "Melder", "", "c|m")
+	wordID := int(id)
+	if err := db.UpdateBannedWordRejectionReason(wordID, "rett ord"); err == nil {
+		t.Error("a reason for a pending word should fail")
+	}
+	if err := db.RejectBannedWord(wordID, "rett"); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.UpdateBannedWordRejectionReason(wordID, "rett ord"); err != nil {
+		t.Fatal(err)
+	}
+
+	bw, _ := db.GetBannedWordByID(wordID)
+	if bw.ApprovalStatus != "rejected" || *bw.RejectedBy != "rett" || bw.RejectedAt == nil || *bw.RejectionReason != "rett ord" {
+		t.Errorf("rejected word = %+v", bw)
+	}
+	if err := db.ApproveBannedWordByOpplysar(wordID, "opp"); err == nil {
+		t.Error("approving a rejected word should fail")
+	}
+
+	// A new report puts the word up for approval again
+	if err := db.ReopenBannedWord(wordID, "ny rapport", "melder2", "Melder2", "c2|m2"); err != nil {
+		t.Fatal(err)
+	}
+	bw, _ = db.GetBannedWordByID(wordID)
+	if bw.ApprovalStatus != "pending" || bw.AuthorID != "melder2" || bw.RejectedBy != nil || bw.RejectionReason != nil || *bw.OriginalMessageID != "c2|m2" {
+		t.Errorf("reopened word = %+v", bw)
+	}
+	if err := db.ReopenBannedWord(wordID, "ny rapport", "melder2", "Melder2", "c2|m2"); err == nil {
+		t.Error("reopening a pending word should fail")
+	}
+}
+
+func TestUnbanProposal(t *testing.T) {
+	db := newTestDB(t, sqliteConfig(t, ""))
+
+	id, _ := db.AddBannedWordPending("ikkje", "", "melder", "Melder", "", "c|m")
+	wordID := int(id)
+	db.ApproveBannedWordCombined(wordID, []string{"opp"}, []string{"rett"})
+
+	first, _ := db.AddUnbanProposal(wordID, "forslag", "Forslag", "er lov")
+	if err := db.RejectUnbanProposal(int(first), "rett"); err != nil {
+		t.Fatal(err)
+	}
+	if bw, _ := db.GetBannedWordByID(wordID); !bw.IsActive() {
+		t.Fatal("a rejected proposal lifted the ban")
+	}
+
+	second, _ := db.AddUnbanProposal(wordID, "forslag", "Forslag", "er lov")
+	if pending, err := db.GetPendingUnbanProposalForWord(wordID); err != nil || pending.ID != int(second) {
+		t.Fatalf("pending proposal = %+v, %v", pending, err)
+	}
+	if err := db.ApproveUnbanProposal(int(second), []string{"opp"}, []string{"rett", "rett2"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.ApproveUnbanProposal(int(second), []string{"opp"}, []string{"rett"}); err == nil {
+		t.Error("approving a decided proposal should fail")
+	}
+
+	bw, _ := db.GetBannedWordByID(wordID)
+	if bw.IsActive() || bw.UnbannedAt == nil || bw.ApprovalStatus != "fully_approved" {
+		t.Errorf("unbanned word = %+v", bw)
+	}
+	p, _ := db.GetUnbanProposalByID(int(second))
+	if p.Status != "approved" || *p.RettskrivarApprovedBy != "rett,rett2" {
+		t.Errorf("approved proposal = %+v", p)
+	}
+}
+
+func TestImportBannedWord(t *testing.T) {
+	db := newTestDB(t, sqliteConfig(t, ""))
+
+	// A new word is added as approved
+	if err := db.ImportBannedWord("ikkje", "bokmål", []string{"ikkje"}, "importør", "Importør"); err != nil {
+		t.Fatal(err)
+	}
+	_, bw, _ := db.IsBannedWord("ikkje")
+	if !bw.IsActive() || bw.Reason != "bokmål" || len(bw.Suggestions) != 1 || *bw.OpplysarApprovedBy != "importør" {
+		t.Fatalf("imported word = %+v", bw)
+	}
+
+	// An approved word keeps its approvers and gets the new reason and suggestions,
+	// and an unbanned word is banned again
+	db.conn.Exec("UPDATE "+db.bannedWordsTable+" SET opplysar_approved_by = 'opp', unbanned_at = ? WHERE id = ?", time.Now().UTC(), bw.ID)
+	if err := db.ImportBannedWord("ikkje", "ny grunn", nil, "importør", "Importør"); err != nil {
+		t.Fatal(err)
+	}
+	bw, _ = db.GetBannedWordByID(bw.ID)
+	if !bw.IsActive() || bw.Reason != "ny grunn" || len(bw.Suggestions) != 0 || *bw.OpplysarApprovedBy != "opp" {
+		t.Errorf("re-imported word = %+v", bw)
+	}
+
+	// A pending or rejected word is approved by the importer
+	id, _ := db.AddBannedWordPending("hvordan", "", "melder", "Melder", "", "c|m")
+	db.RejectBannedWord(int(id), "rett")
+	if err := db.ImportBannedWord("hvordan", "bokmål", nil, "importør", "Importør"); err != nil {
+		t.Fatal(err)
+	}
+	bw, _ = db.GetBannedWordByID(int(id))
+	if !bw.IsActive() || *bw.RettskrivarApprovedBy != "importør" || bw.AuthorID != "melder" {
+		t.Errorf("imported rejected word = %+v", bw)
+	}
+}
+
+func TestWarningCooldowns(t *testing.T) {
+	db := newTestDB(t, sqliteConfig(t, ""))
+
+	id, err := db.AddBannedWordWarning("u1", 1, "kanal", "melding", "kanal", "åtvaring")
+	if err != nil {
+		t.Fatal(err)
+	}
+	warningID := int(id)
+
+	w, err := db.GetRecentBannedWordWarning("u1", 1, time.Hour)
+	if err != nil || w == nil || w.ID != warningID || w.HitCount != 1 {
+		t.Fatalf("recent warning = %+v, %v", w, err)
+	}
+	if w, _ := db.GetRecentBannedWordWarning("u1", 2, time.Hour); w != nil {
+		t.Errorf("warning for another word = %+v", w)
+	}
+	if recent, _ := db.HasRecentWarningInChannel("kanal", time.Hour); !recent {
+		t.Error("no recent warning in the channel")
+	}
+
+	// Move the warning out of the cooldown
+	old := time.Now().UTC().Add(-2 * time.Hour)
+	db.conn.Exec("UPDATE "+db.warningsTable+" SET created_at = ?, last_hit_at = ? WHERE id = ?", old, old, warningID)
+	if w, _ := db.GetRecentBannedWordWarning("u1", 1, time.Hour); w != nil {
+		t.Errorf("warning outside the cooldown = %+v", w)
+	}
+	if recent, _ := db.HasRecentWarningInChannel("kanal", time.Hour); recent {
+		t.Error("old warning counted as recent in the channel")
+	}
+
+	// A new hit restarts the cooldown, but not the channel's
+	if err := db.RecordBannedWordWarningHit(warningID); err != nil {
+		t.Fatal(err)
+	}
+	if w, _ := db.GetRecentBannedWordWarning("u1", 1, time.Hour); w == nil || w.HitCount != 2 {
+		t.Errorf("warning after a hit = %+v", w)
+	}
+	if recent, _ := db.HasRecentWarningInChannel("kanal", time.Hour); recent {
+		t.Error("a hit counted as a new warning in the channel")
+	}
+}
+
+func TestQuestionPosts(t *testing.T) {
+	db := newTestDB(t, sqliteConfig(t, ""))
+
+	id, _ := db.AddQuestion("Kva et du?", "u1", "brukar", "m", "c")
+	db.AddQuestionPost(int(id), "post1", "", "kanal", QuestionTriggerMorning, "")
+	db.AddQuestionPost(int(id), "post2", "traad", "kanal", QuestionTriggerPoke, "admin")
+	db.AddQuestionPost(99, "post3", "", "kanal", QuestionTriggerInactivity, "")
+
+	if n, err := db.CountQuestionPosts(); err != nil || n != 3 {
+		t.Fatalf("CountQuestionPosts() = %d, %v", n, err)
+	}
+
+	posts, err := db.GetQuestionPosts(0, 2)
+	if err != nil || len(posts) != 2 {
+		t.Fatalf("GetQuestionPosts() = %+v, %v", posts, err)
+	}
+	if posts[0].MessageID != "post3" || posts[0].Question != "" || posts[0].ThreadID != nil {
+		t.Errorf("newest post = %+v", posts[0])
+	}
+	p := posts[1]
+	if p.Question != "Kva et du?" || p.Trigger != QuestionTriggerPoke || *p.ThreadID != "traad" || *p.TriggeredBy != "admin" {
+		t.Errorf("poke post = %+v", p)
+	}
+
+	last, _ := db.GetQuestionPosts(2, 2)
+	if len(last) != 1 || last[0].MessageID != "post1" || last[0].TriggeredBy != nil {
+		t.Errorf("last page = %+v", last)
+	}
+}
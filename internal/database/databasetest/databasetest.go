@@ -0,0 +1,653 @@
+// Package databasetest tilbyr ein trådsikker minnedatabase som implementerer
+// database.DatabaseIface, slik at kommandoar og reaksjonar kan testast utan MySQL.
+package databasetest
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"askeladden/internal/database"
+)
+
+// DB is an in-memory implementation of database.DatabaseIface. It mirrors the
+// SQL implementation closely: the same status transitions are allowed, the same
+// lookups return sql.ErrNoRows, and ordering follows the same ORDER BY clauses.
+type DB struct {
+	mu sync.Mutex
+
+	// Now returns the current time and can be replaced to control timestamps
+	Now func() time.Time
+
+	nextQuestionID     int
+	nextBannedWordID   int
+	questions          []*database.Question
+	bannedWords        []*database.BannedWord
+	nextUnbanID        int
+	unbanProposals     []*database.UnbanProposal
+	starboard          map[string]*database.StarboardMessage
+	warningModes       map[string]string
+	nextWarningID      int
+	warnings           []*database.BannedWordWarning
+	hits               []bannedWordHit
+	nextAllowlistID    int
+	allowlist          []*database.AllowedPhrase
+	nextQuestionPostID int
+	questionPosts      []*database.QuestionPost
+}
+
+var _ database.DatabaseIface = (*DB)(nil)
+
+// New creates an empty in-memory database
+func New() *DB {
+	return &DB{
+		Now:          time.Now,
+		starboard:    make(map[string]*database.StarboardMessage),
+		warningModes: make(map[string]string),
+	}
+}
+
+// copyQuestion returns a copy so callers never share state with the fake
+func copyQuestion(q *database.Question) *database.Question {
+	c := *q
+	return &c
+}
+
+// copyBannedWord returns a copy so callers never share state with the fake
+func copyBannedWord(bw *database.BannedWord) *database.BannedWord {
+	c := *bw
+	c.Patterns = append([]string(nil), bw.Patterns...)
+	c.Suggestions = append([]string(nil), bw.Suggestions...)
+	c.Exceptions = append([]string(nil), bw.Exceptions...)
+	return &c
+}
+
+// stringPtr returns a pointer to s, or nil for the empty string (NULL)
+func stringPtr(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// timePtr returns a pointer to a copy of t
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+// findQuestion returns the stored question with the given ID, or nil
+func (db *DB) findQuestion(questionID int) *database.Question {
+	for _, q := range db.questions {
+		if q.ID == questionID {
+			return q
+		}
+	}
+	return nil
+}
+
+// findBannedWord returns the stored banned word with the given ID, or nil
+func (db *DB) findBannedWord(wordID int) *database.BannedWord {
+	for _, bw := range db.bannedWords {
+		if bw.ID == wordID {
+			return bw
+		}
+	}
+	return nil
+}
+
+// questionsByStatus returns stored questions with the given status in insertion order
+func (db *DB) questionsByStatus(status string) []*database.Question {
+	var result []*database.Question
+	for _, q := range db.questions {
+		if q.ApprovalStatus == status {
+			result = append(result, q)
+		}
+	}
+	return result
+}
+
+// AddQuestion adds a new pending question
+func (db *DB) AddQuestion(question, authorID, authorName, messageID, channelID string) (int64, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.nextQuestionID++
+	db.questions = append(db.questions, &database.Question{
+		ID:             db.nextQuestionID,
+		Question:       question,
+		AuthorID:       authorID,
+		AuthorName:     authorName,
+		CreatedAt:      db.Now(),
+		MessageID:      messageID,
+		ChannelID:      channelID,
+		ApprovalStatus: "pending",
+	})
+	return int64(db.nextQuestionID), nil
+}
+
+// GetQuestionByMessageID gets a question by its Discord message ID
+func (db *DB) GetQuestionByMessageID(messageID string) (*database.Question, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for _, q := range db.questions {
+		if q.MessageID == messageID {
+			return copyQuestion(q), nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+// ApproveQuestion marks a question as approved
+func (db *DB) ApproveQuestion(questionID int, approverID string) error {
+	return db.setQuestionStatus(questionID, "approved", approverID)
+}
+
+// RejectQuestion marks a question as rejected
+func (db *DB) RejectQuestion(questionID int, rejectorID string) error {
+	return db.setQuestionStatus(questionID, "rejected", rejectorID)
+}
+
+// setQuestionStatus updates status like the SQL UPDATE does: no error when missing
+func (db *DB) setQuestionStatus(questionID int, status, userID string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if q := db.findQuestion(questionID); q != nil {
+		q.ApprovalStatus = status
+		q.ApprovedBy = stringPtr(userID)
+		q.ApprovedAt = timePtr(db.Now())
+	}
+	return nil
+}
+
+// GetPendingQuestion returns the oldest pending question, or nil if there is none
+func (db *DB) GetPendingQuestion() (*database.Question, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	pending := db.questionsByStatus("pending")
+	if len(pending) == 0 {
+		return nil, nil
+	}
+	sort.SliceStable(pending, func(i, j int) bool {
+		return pending[i].CreatedAt.Before(pending[j].CreatedAt)
+	})
+	return copyQuestion(pending[0]), nil
+}
+
+// UpdateApprovalMessageID updates the approval message ID for a question
+func (db *DB) UpdateApprovalMessageID(questionID int, approvalMessageID string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if q := db.findQuestion(questionID); q != nil {
+		q.ApprovalMessageID = stringPtr(approvalMessageID)
+	}
+	return nil
+}
+
+// GetQuestionByApprovalMessageID gets a question by its approval message ID
+func (db *DB) GetQuestionByApprovalMessageID(approvalMessageID string) (*database.Question, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for _, q := range db.questions {
+		if q.ApprovalMessageID != nil && *q.ApprovalMessageID == approvalMessageID {
+			return copyQuestion(q), nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+// GetPendingQuestionByID gets a question by ID, but only while it is pending
+func (db *DB) GetPendingQuestionByID(questionID int) (*database.Question, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	q := db.findQuestion(questionID)
+	if q == nil || q.ApprovalStatus != "pending" {
+		return nil, sql.ErrNoRows
+	}
+	return copyQuestion(q), nil
+}
+
+// GetApprovalStats returns pending, approved and rejected question counts
+func (db *DB) GetApprovalStats() (int, int, int, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	return len(db.questionsByStatus("pending")), len(db.questionsByStatus("approved")), len(db.questionsByStatus("rejected")), nil
+}
+
+// GetApprovedQuestions returns every approved question, oldest first
+func (db *DB) GetApprovedQuestions() ([]*database.Question, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	var questions []*database.Question
+	for _, q := range db.questionsByStatus("approved") {
+		questions = append(questions, copyQuestion(q))
+	}
+	sort.SliceStable(questions, func(i, j int) bool {
+		return questions[i].CreatedAt.Before(questions[j].CreatedAt)
+	})
+	return questions, nil
+}
+
+// IncrementQuestionUsage bumps times_asked and sets last_asked_at
+func (db *DB) IncrementQuestionUsage(questionID int) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if q := db.findQuestion(questionID); q != nil {
+		q.TimesAsked++
+		q.LastAskedAt = timePtr(db.Now())
+	}
+	return nil
+}
+
+// GetApprovedQuestionStats returns approved count, total times asked and minimum times asked
+func (db *DB) GetApprovedQuestionStats() (int, int, int, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	approved := db.questionsByStatus("approved")
+	totalAsked, minAsked := 0, 0
+	for i, q := range approved {
+		totalAsked += q.TimesAsked
+		if i == 0 || q.TimesAsked < minAsked {
+			minAsked = q.TimesAsked
+		}
+	}
+	return len(approved), totalAsked, minAsked, nil
+}
+
+// insertBannedWord stores a banned word, enforcing the UNIQUE constraint on word
+func (db *DB) insertBannedWord(bw *database.BannedWord) (int64, error) {
+	for _, existing := range db.bannedWords {
+		if strings.EqualFold(existing.Word, bw.Word) {
+			return 0, fmt.Errorf("duplicate entry '%s' for key 'word'", bw.Word)
+		}
+	}
+
+	db.nextBannedWordID++
+	bw.ID = db.nextBannedWordID
+	bw.CreatedAt = db.Now()
+	bw.Category = database.CategoryBokmal
+	bw.Severity = database.SeverityMedium
+	db.bannedWords = append(db.bannedWords, bw)
+	return int64(bw.ID), nil
+}
+
+// AddBannedWord adds a new banned word in the default pending state
+func (db *DB) AddBannedWord(word, reason, authorID string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	_, err := db.insertBannedWord(&database.BannedWord{
+		Word:           word,
+		Reason:         reason,
+		AuthorID:       authorID,
+		ApprovalStatus: "pending",
+	})
+	return err
+}
+
+// AddBannedWordPending adds a new banned word in pending approval state
+func (db *DB) AddBannedWordPending(word, reason, authorID, authorName, forumThreadID, originalMessageID string) (int64, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	return db.insertBannedWord(&database.BannedWord{
+		Word:              word,
+		Reason:            reason,
+		AuthorID:          authorID,
+		AuthorName:        authorName,
+		ForumThreadID:     &forumThreadID,
+		OriginalMessageID: &originalMessageID,
+		ApprovalStatus:    "pending",
+	})
+}
+
+// UpdateBannedWordApprovalMessageID updates the approval message ID for a banned word
+func (db *DB) UpdateBannedWordApprovalMessageID(wordID int, approvalMessageID string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if bw := db.findBannedWord(wordID); bw != nil {
+		bw.ApprovalMessageID = stringPtr(approvalMessageID)
+	}
+	return nil
+}
+
+// GetBannedWordByApprovalMessageID gets a banned word by its approval message ID
+func (db *DB) GetBannedWordByApprovalMessageID(approvalMessageID string) (*database.BannedWord, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for _, bw := range db.bannedWords {
+		if bw.ApprovalMessageID != nil && *bw.ApprovalMessageID == approvalMessageID {
+			return copyBannedWord(bw), nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+// transitionBannedWord moves a banned word from one status to another and
+// fails like the SQL implementation when the word is not in the expected state
+func (db *DB) transitionBannedWord(wordID int, from, to string, update func(bw *database.BannedWord)) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	bw := db.findBannedWord(wordID)
+	if bw == nil || bw.ApprovalStatus != from {
+		return fmt.Errorf("no %s banned word found for %s", from, to)
+	}
+	bw.ApprovalStatus = to
+	update(bw)
+	return nil
+}
+
+// ApproveBannedWordByOpplysar moves a pending banned word to opplysar_approved
+func (db *DB) ApproveBannedWordByOpplysar(wordID int, approverID string) error {
+	return db.transitionBannedWord(wordID, "pending", "opplysar_approved", func(bw *database.BannedWord) {
+		bw.OpplysarApprovedBy = stringPtr(approverID)
+		bw.OpplysarApprovedAt = timePtr(db.Now())
+	})
+}
+
+// ApproveBannedWordByRettskrivar moves an opplysar_approved banned word to fully_approved
+func (db *DB) ApproveBannedWordByRettskrivar(wordID int, approverID string) error {
+	return db.transitionBannedWord(wordID, "opplysar_approved", "fully_approved", func(bw *database.BannedWord) {
+		bw.RettskrivarApprovedBy = stringPtr(approverID)
+		bw.RettskrivarApprovedAt = timePtr(db.Now())
+	})
+}
+
+// ApproveBannedWordCombined moves a pending banned word straight to fully_approved
+func (db *DB) ApproveBannedWordCombined(wordID int, opplysarApprovers, rettskrivarApprovers []string) error {
+	return db.transitionBannedWord(wordID, "pending", "fully_approved", func(bw *database.BannedWord) {
+		now := db.Now()
+		bw.OpplysarApprovedBy = stringPtr(strings.Join(opplysarApprovers, ","))
+		bw.RettskrivarApprovedBy = stringPtr(strings.Join(rettskrivarApprovers, ","))
+		bw.OpplysarApprovedAt = timePtr(now)
+		bw.RettskrivarApprovedAt = timePtr(now)
+	})
+}
+
+// UpdateBannedWordForumThreadID updates the forum thread ID for a banned word
+func (db *DB) UpdateBannedWordForumThreadID(wordID int, forumThreadID string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if bw := db.findBannedWord(wordID); bw != nil {
+		bw.ForumThreadID = stringPtr(forumThreadID)
+	}
+	return nil
+}
+
+// UpdateBannedWordPatterns replaces the extra match patterns for a banned word
+func (db *DB) UpdateBannedWordPatterns(wordID int, patterns []string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	bw := db.findBannedWord(wordID)
+	if bw == nil {
+		return fmt.Errorf("no banned word found with ID %d", wordID)
+	}
+	bw.Patterns = append([]string(nil), patterns...)
+	return nil
+}
+
+// UpdateBannedWordSuggestions replaces the suggested alternatives for a banned word
+func (db *DB) UpdateBannedWordSuggestions(wordID int, suggestions []string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	bw := db.findBannedWord(wordID)
+	if bw == nil {
+		return fmt.Errorf("no banned word found with ID %d", wordID)
+	}
+	bw.Suggestions = append([]string(nil), suggestions...)
+	return nil
+}
+
+// UpdateBannedWordCategory sets the category and severity of a banned word
+func (db *DB) UpdateBannedWordCategory(wordID int, category, severity string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	bw := db.findBannedWord(wordID)
+	if bw == nil {
+		return fmt.Errorf("no banned word found with ID %d", wordID)
+	}
+	bw.Category = category
+	bw.Severity = severity
+	return nil
+}
+
+// ImportBannedWord adds or updates a fully approved banned word from a word list
+func (db *DB) ImportBannedWord(word, reason string, suggestions []string, importerID, importerName string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	var bw *database.BannedWord
+	for _, existing := range db.bannedWords {
+		if strings.EqualFold(existing.Word, word) {
+			bw = existing
+			break
+		}
+	}
+	if bw == nil {
+		bw = &database.BannedWord{Word: word, AuthorID: importerID, AuthorName: importerName}
+		if _, err := db.insertBannedWord(bw); err != nil {
+			return err
+		}
+	}
+
+	bw.Reason = reason
+	bw.Suggestions = append([]string(nil), suggestions...)
+	bw.UnbannedAt = nil
+	if bw.ApprovalStatus != "fully_approved" {
+		now := db.Now()
+		bw.ApprovalStatus = "fully_approved"
+		bw.OpplysarApprovedBy = stringPtr(importerID)
+		bw.OpplysarApprovedAt = timePtr(now)
+		bw.RettskrivarApprovedBy = stringPtr(importerID)
+		bw.RettskrivarApprovedAt = timePtr(now)
+	}
+	return nil
+}
+
+// RejectBannedWord moves a pending banned word to rejected
+func (db *DB) RejectBannedWord(wordID int, rejectorID string) error {
+	return db.transitionBannedWord(wordID, "pending", "rejected", func(bw *database.BannedWord) {
+		bw.RejectedBy = stringPtr(rejectorID)
+		bw.RejectedAt = timePtr(db.Now())
+	})
+}
+
+// UpdateBannedWordRejectionReason sets the reason given for a rejected banned word
+func (db *DB) UpdateBannedWordRejectionReason(wordID int, reason string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	bw := db.findBannedWord(wordID)
+	if bw == nil || bw.ApprovalStatus != "rejected" {
+		return fmt.Errorf("no rejected banned word found with ID %d", wordID)
+	}
+	bw.RejectionReason = stringPtr(reason)
+	return nil
+}
+
+// ReopenBannedWord puts a rejected or unbanned word back up for approval
+func (db *DB) ReopenBannedWord(wordID int, reason, reporterID, reporterName, originalMessageID string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	bw := db.findBannedWord(wordID)
+	if bw == nil || (bw.ApprovalStatus != "rejected" && bw.UnbannedAt == nil) {
+		return fmt.Errorf("no rejected or unbanned banned word found with ID %d", wordID)
+	}
+	bw.ApprovalStatus = "pending"
+	bw.Reason = reason
+	bw.AuthorID = reporterID
+	bw.AuthorName = reporterName
+	bw.OriginalMessageID = stringPtr(originalMessageID)
+	bw.ApprovalMessageID = nil
+	bw.OpplysarApprovedBy, bw.OpplysarApprovedAt = nil, nil
+	bw.RettskrivarApprovedBy, bw.RettskrivarApprovedAt = nil, nil
+	bw.RejectedBy, bw.RejectedAt, bw.RejectionReason = nil, nil, nil
+	bw.UnbannedAt = nil
+	return nil
+}
+
+// GetPendingBannedWord returns the oldest pending banned word, or nil if there is none
+func (db *DB) GetPendingBannedWord() (*database.BannedWord, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	var oldest *database.BannedWord
+	for _, bw := range db.bannedWords {
+		if bw.ApprovalStatus == "pending" && (oldest == nil || bw.CreatedAt.Before(oldest.CreatedAt)) {
+			oldest = bw
+		}
+	}
+	if oldest == nil {
+		return nil, nil
+	}
+	return copyBannedWord(oldest), nil
+}
+
+// GetBannedWordByID gets a banned word by its ID
+func (db *DB) GetBannedWordByID(wordID int) (*database.BannedWord, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	bw := db.findBannedWord(wordID)
+	if bw == nil {
+		return nil, sql.ErrNoRows
+	}
+	return copyBannedWord(bw), nil
+}
+
+// GetBannedWordApprovalStats returns banned word counts per approval status
+func (db *DB) GetBannedWordApprovalStats() (int, int, int, int, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	counts := make(map[string]int)
+	for _, bw := range db.bannedWords {
+		counts[bw.ApprovalStatus]++
+	}
+	return counts["pending"], counts["opplysar_approved"], counts["fully_approved"], counts["rejected"], nil
+}
+
+// RemoveBannedWord deletes a banned word
+func (db *DB) RemoveBannedWord(word string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for i, bw := range db.bannedWords {
+		if strings.EqualFold(bw.Word, word) {
+			db.bannedWords = append(db.bannedWords[:i], db.bannedWords[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// IsBannedWord checks if a word is in the banned words table (any status)
+func (db *DB) IsBannedWord(word string) (bool, *database.BannedWord, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for _, bw := range db.bannedWords {
+		if strings.EqualFold(bw.Word, word) {
+			return true, copyBannedWord(bw), nil
+		}
+	}
+	return false, nil, nil
+}
+
+// GetBannedWords returns all banned words, newest first
+func (db *DB) GetBannedWords() ([]*database.BannedWord, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	words := make([]*database.BannedWord, 0, len(db.bannedWords))
+	for i := len(db.bannedWords) - 1; i >= 0; i-- {
+		words = append(words, copyBannedWord(db.bannedWords[i]))
+	}
+	sort.SliceStable(words, func(i, j int) bool {
+		return words[i].CreatedAt.After(words[j].CreatedAt)
+	})
+	return words, nil
+}
+
+// AddStarboardMessage adds a starboard mapping, enforcing the UNIQUE original message
+func (db *DB) AddStarboardMessage(originalMessageID, starboardMessageID, channelID string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if _, exists := db.starboard[originalMessageID]; exists {
+		return fmt.Errorf("duplicate entry '%s' for key 'original_message_id'", originalMessageID)
+	}
+	db.starboard[originalMessageID] = &database.StarboardMessage{
+		ID:                 len(db.starboard) + 1,
+		OriginalMessageID:  originalMessageID,
+		StarboardMessageID: starboardMessageID,
+		ChannelID:          channelID,
+		CreatedAt:          db.Now(),
+	}
+	return nil
+}
+
+// GetStarboardMessage returns the starboard message ID, or "" if there is none
+func (db *DB) GetStarboardMessage(originalMessageID string) (string, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if sm, exists := db.starboard[originalMessageID]; exists {
+		return sm.StarboardMessageID, nil
+	}
+	return "", nil
+}
+
+// UpdateStarboardMessage updates the starboard message ID for an original message
+func (db *DB) UpdateStarboardMessage(originalMessageID, starboardMessageID string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if sm, exists := db.starboard[originalMessageID]; exists {
+		sm.StarboardMessageID = starboardMessageID
+	}
+	return nil
+}
+
+// RemoveStarboardMessage removes a starboard mapping
+func (db *DB) RemoveStarboardMessage(originalMessageID string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	delete(db.starboard, originalMessageID)
+	return nil
+}
+
+// Close does nothing for the in-memory database
+func (db *DB) Close() error {
+	return nil
+}
+
+// ClearDatabase deletes all questions, like the SQL implementation
+func (db *DB) ClearDatabase() error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.questions = nil
+	return nil
+}
@@ -0,0 +1,118 @@
+package databasetest
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+)
+
+// fixedClock returns a clock that advances one minute per call
+func fixedClock() func() time.Time {
+	now := time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC)
+	return func() time.Time {
+		now = now.Add(time.Minute)
+		return now
+	}
+}
+
+func TestApprovedQuestionsAndUsage(t *testing.T) {
+	db := New()
+	db.Now = fixedClock()
+
+	for _, q := range []string{"Første?", "Andre?", "Tredje?"} {
+		id, _ := db.AddQuestion(q, "u1", "brukar", "m", "c")
+		db.ApproveQuestion(int(id), "opplysar")
+	}
+	db.IncrementQuestionUsage(2)
+	db.IncrementQuestionUsage(2)
+	db.IncrementQuestionUsage(3)
+
+	approved, err := db.GetApprovedQuestions()
+	if err != nil || len(approved) != 3 {
+		t.Fatalf("GetApprovedQuestions() = %v, %v", approved, err)
+	}
+	for i, want := range []struct {
+		question   string
+		timesAsked int
+	}{{"Første?", 0}, {"Andre?", 2}, {"Tredje?", 1}} {
+		q := approved[i]
+		if q.Question != want.question || q.TimesAsked != want.timesAsked {
+			t.Errorf("approved[%d] = %q asked %d times, want %q asked %d", i, q.Question, q.TimesAsked, want.question, want.timesAsked)
+		}
+	}
+	if approved[0].LastAskedAt != nil || approved[1].LastAskedAt == nil || !approved[2].LastAskedAt.After(*approved[1].LastAskedAt) {
+		t.Errorf("last asked = %v, %v, %v", approved[0].LastAskedAt, approved[1].LastAskedAt, approved[2].LastAskedAt)
+	}
+
+	total, totalAsked, minAsked, _ := db.GetApprovedQuestionStats()
+	if total != 3 || totalAsked != 3 || minAsked != 0 {
+		t.Errorf("stats = %d, %d, %d, want 3, 3, 0", total, totalAsked, minAsked)
+	}
+}
+
+func TestApprovedQuestionsIgnorePendingAndRejected(t *testing.T) {
+	db := New()
+	db.AddQuestion("Ventar", "u1", "brukar", "m1", "c")
+	id, _ := db.AddQuestion("Avvist", "u1", "brukar", "m2", "c")
+	db.RejectQuestion(int(id), "opplysar")
+
+	approved, err := db.GetApprovedQuestions()
+	if len(approved) != 0 || err != nil {
+		t.Fatalf("GetApprovedQuestions() = %v, %v, want none", approved, err)
+	}
+
+	if _, err := db.GetPendingQuestionByID(int(id)); err != sql.ErrNoRows {
+		t.Errorf("GetPendingQuestionByID(rejected) error = %v, want sql.ErrNoRows", err)
+	}
+}
+
+func TestBannedWordTransitions(t *testing.T) {
+	db := New()
+	id, err := db.AddBannedWordPending("ikkje", "", "u1", "brukar", "", "c|m")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wordID := int(id)
+
+	if err := db.ApproveBannedWordByRettskrivar(wordID, "r1"); err == nil {
+		t.Error("rettskrivar approval of a pending word should fail")
+	}
+	if err := db.ApproveBannedWordByOpplysar(wordID, "o1"); err != nil {
+		t.Fatalf("opplysar approval: %v", err)
+	}
+	if err := db.RejectBannedWord(wordID, "o2"); err == nil {
+		t.Error("rejecting an opplysar approved word should fail")
+	}
+	if err := db.ApproveBannedWordByRettskrivar(wordID, "r1"); err != nil {
+		t.Fatalf("rettskrivar approval: %v", err)
+	}
+
+	bw, _ := db.GetBannedWordByID(wordID)
+	if bw.ApprovalStatus != "fully_approved" {
+		t.Errorf("status = %q, want fully_approved", bw.ApprovalStatus)
+	}
+	if err := db.ApproveBannedWordCombined(wordID, []string{"o1"}, []string{"r1"}); err == nil {
+		t.Error("combined approval of an approved word should fail")
+	}
+
+	if _, err := db.AddBannedWordPending("ikkje", "", "u2", "brukar", "", ""); err == nil {
+		t.Error("adding a duplicate word should fail")
+	}
+
+	pending, opplysar, full, rejected, _ := db.GetBannedWordApprovalStats()
+	if pending != 0 || opplysar != 0 || full != 1 || rejected != 0 {
+		t.Errorf("stats = %d, %d, %d, %d, want 0, 0, 1, 0", pending, opplysar, full, rejected)
+	}
+}
+
+func TestReturnedValuesAreCopies(t *testing.T) {
+	db := New()
+	id, _ := db.AddQuestion("Spørsmål?", "u1", "brukar", "m", "c")
+
+	q, _ := db.GetPendingQuestionByID(int(id))
+	q.ApprovalStatus = "approved"
+
+	if again, _ := db.GetPendingQuestionByID(int(id)); again == nil || again.ApprovalStatus != "pending" {
+		t.Error("mutating a returned question changed the stored one")
+	}
+}
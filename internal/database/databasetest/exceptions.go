@@ -0,0 +1,71 @@
+package databasetest
+
+import (
+	"fmt"
+	"sort"
+
+	"askeladden/internal/database"
+)
+
+// UpdateBannedWordExceptions replaces the exceptions for a banned word
+func (db *DB) UpdateBannedWordExceptions(wordID int, exceptions []string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	bw := db.findBannedWord(wordID)
+	if bw == nil {
+		return fmt.Errorf("no banned word found with ID %d", wordID)
+	}
+	bw.Exceptions = append([]string(nil), exceptions...)
+	return nil
+}
+
+// GetAllowedPhrases returns the global allowlist in alphabetical order
+func (db *DB) GetAllowedPhrases() ([]*database.AllowedPhrase, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	var phrases []*database.AllowedPhrase
+	for _, p := range db.allowlist {
+		c := *p
+		phrases = append(phrases, &c)
+	}
+	sort.Slice(phrases, func(i, j int) bool { return phrases[i].Phrase < phrases[j].Phrase })
+	return phrases, nil
+}
+
+// AddAllowedPhrase adds a phrase to the global allowlist. Like the UNIQUE
+// column, adding a phrase twice fails.
+func (db *DB) AddAllowedPhrase(phrase, addedBy string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for _, p := range db.allowlist {
+		if p.Phrase == phrase {
+			return fmt.Errorf("duplicate allowed phrase %q", phrase)
+		}
+	}
+	db.nextAllowlistID++
+	db.allowlist = append(db.allowlist, &database.AllowedPhrase{
+		ID:        db.nextAllowlistID,
+		Phrase:    phrase,
+		AddedBy:   addedBy,
+		CreatedAt: db.Now(),
+	})
+	return nil
+}
+
+// RemoveAllowedPhrase removes a phrase from the global allowlist and reports
+// whether it was there
+func (db *DB) RemoveAllowedPhrase(phrase string) (bool, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for i, p := range db.allowlist {
+		if p.Phrase == phrase {
+			db.allowlist = append(db.allowlist[:i], db.allowlist[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,60 @@
+package databasetest
+
+import (
+	"sort"
+
+	"askeladden/internal/database"
+)
+
+// AddQuestionPost records that a daily question was posted
+func (db *DB) AddQuestionPost(questionID int, messageID, threadID, channelID, trigger, triggeredBy string) (int64, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.nextQuestionPostID++
+	db.questionPosts = append(db.questionPosts, &database.QuestionPost{
+		ID:          db.nextQuestionPostID,
+		QuestionID:  questionID,
+		MessageID:   messageID,
+		ThreadID:    stringPtr(threadID),
+		ChannelID:   channelID,
+		Trigger:     trigger,
+		TriggeredBy: stringPtr(triggeredBy),
+		PostedAt:    db.Now(),
+	})
+	return int64(db.nextQuestionPostID), nil
+}
+
+// GetQuestionPosts returns posted daily questions, newest first
+func (db *DB) GetQuestionPosts(offset, limit int) ([]*database.QuestionPost, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	var posts []*database.QuestionPost
+	for _, p := range db.questionPosts {
+		c := *p
+		if q := db.findQuestion(p.QuestionID); q != nil {
+			c.Question = q.Question
+		}
+		posts = append(posts, &c)
+	}
+	sort.SliceStable(posts, func(i, j int) bool {
+		if !posts[i].PostedAt.Equal(posts[j].PostedAt) {
+			return posts[i].PostedAt.After(posts[j].PostedAt)
+		}
+		return posts[i].ID > posts[j].ID
+	})
+
+	if offset >= len(posts) {
+		return nil, nil
+	}
+	return posts[offset:min(offset+limit, len(posts))], nil
+}
+
+// CountQuestionPosts returns how many daily questions have been posted
+func (db *DB) CountQuestionPosts() (int, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	return len(db.questionPosts), nil
+}
@@ -0,0 +1,92 @@
+package databasetest
+
+import (
+	"sort"
+	"time"
+
+	"askeladden/internal/database"
+)
+
+// bannedWordHit is a logged use of a banned word
+type bannedWordHit struct {
+	wordID    int
+	userID    string
+	channelID string
+	messageID string
+	createdAt time.Time
+}
+
+// AddBannedWordHit logs a detected banned word
+func (db *DB) AddBannedWordHit(wordID int, userID, channelID, messageID string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.hits = append(db.hits, bannedWordHit{
+		wordID:    wordID,
+		userID:    userID,
+		channelID: channelID,
+		messageID: messageID,
+		createdAt: db.Now(),
+	})
+	return nil
+}
+
+// GetBannedWordHitWordIDs returns the IDs of the banned words logged for a message
+func (db *DB) GetBannedWordHitWordIDs(messageID string) ([]int, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	var wordIDs []int
+	seen := make(map[int]bool)
+	for _, hit := range db.hits {
+		if hit.messageID == messageID && !seen[hit.wordID] {
+			seen[hit.wordID] = true
+			wordIDs = append(wordIDs, hit.wordID)
+		}
+	}
+	return wordIDs, nil
+}
+
+// GetTopBannedWords returns the most used banned words since the given time
+func (db *DB) GetTopBannedWords(userID string, since time.Time, limit int) ([]database.BannedWordHitCount, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	counts := make(map[int]int)
+	for _, hit := range db.hits {
+		if !hit.createdAt.Before(since) && (userID == "" || hit.userID == userID) {
+			counts[hit.wordID]++
+		}
+	}
+
+	var top []database.BannedWordHitCount
+	for _, bw := range db.bannedWords {
+		if counts[bw.ID] > 0 {
+			top = append(top, database.BannedWordHitCount{WordID: bw.ID, Word: bw.Word, Count: counts[bw.ID]})
+		}
+	}
+	sort.Slice(top, func(i, j int) bool {
+		if top[i].Count != top[j].Count {
+			return top[i].Count > top[j].Count
+		}
+		return top[i].Word < top[j].Word
+	})
+	if len(top) > limit {
+		top = top[:limit]
+	}
+	return top, nil
+}
+
+// CountBannedWordHits counts hits from (inclusive) to (exclusive)
+func (db *DB) CountBannedWordHits(userID string, from, to time.Time) (int, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	count := 0
+	for _, hit := range db.hits {
+		if !hit.createdAt.Before(from) && hit.createdAt.Before(to) && (userID == "" || hit.userID == userID) {
+			count++
+		}
+	}
+	return count, nil
+}
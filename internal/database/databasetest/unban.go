@@ -0,0 +1,128 @@
+package databasetest
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"askeladden/internal/database"
+)
+
+// copyUnbanProposal returns a copy so callers never share state with the fake
+func copyUnbanProposal(p *database.UnbanProposal) *database.UnbanProposal {
+	c := *p
+	return &c
+}
+
+// findUnbanProposal returns the stored proposal with the given ID, or nil
+func (db *DB) findUnbanProposal(proposalID int) *database.UnbanProposal {
+	for _, p := range db.unbanProposals {
+		if p.ID == proposalID {
+			return p
+		}
+	}
+	return nil
+}
+
+// AddUnbanProposal adds a pending proposal to lift the ban on a word
+func (db *DB) AddUnbanProposal(wordID int, proposerID, proposerName, reason string) (int64, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.nextUnbanID++
+	db.unbanProposals = append(db.unbanProposals, &database.UnbanProposal{
+		ID:           db.nextUnbanID,
+		WordID:       wordID,
+		ProposerID:   proposerID,
+		ProposerName: proposerName,
+		Reason:       reason,
+		Status:       "pending",
+		CreatedAt:    db.Now(),
+	})
+	return int64(db.nextUnbanID), nil
+}
+
+// UpdateUnbanProposalApprovalMessageID updates the retting channel message for a proposal
+func (db *DB) UpdateUnbanProposalApprovalMessageID(proposalID int, approvalMessageID string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if p := db.findUnbanProposal(proposalID); p != nil {
+		p.ApprovalMessageID = stringPtr(approvalMessageID)
+	}
+	return nil
+}
+
+// GetUnbanProposalByID returns sql.ErrNoRows if there is no such proposal
+func (db *DB) GetUnbanProposalByID(proposalID int) (*database.UnbanProposal, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	p := db.findUnbanProposal(proposalID)
+	if p == nil {
+		return nil, sql.ErrNoRows
+	}
+	return copyUnbanProposal(p), nil
+}
+
+// GetUnbanProposalByApprovalMessageID returns sql.ErrNoRows if nothing matches
+func (db *DB) GetUnbanProposalByApprovalMessageID(approvalMessageID string) (*database.UnbanProposal, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for _, p := range db.unbanProposals {
+		if p.ApprovalMessageID != nil && *p.ApprovalMessageID == approvalMessageID {
+			return copyUnbanProposal(p), nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+// GetPendingUnbanProposalForWord returns nil, nil if there is no pending proposal
+func (db *DB) GetPendingUnbanProposalForWord(wordID int) (*database.UnbanProposal, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for _, p := range db.unbanProposals {
+		if p.WordID == wordID && p.Status == "pending" {
+			return copyUnbanProposal(p), nil
+		}
+	}
+	return nil, nil
+}
+
+// ApproveUnbanProposal approves a pending proposal and lifts the ban on its word
+func (db *DB) ApproveUnbanProposal(proposalID int, opplysarApprovers, rettskrivarApprovers []string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	p := db.findUnbanProposal(proposalID)
+	if p == nil || p.Status != "pending" {
+		return fmt.Errorf("no pending unban proposal found for approval")
+	}
+	now := db.Now()
+	p.Status = "approved"
+	p.OpplysarApprovedBy = stringPtr(strings.Join(opplysarApprovers, ","))
+	p.RettskrivarApprovedBy = stringPtr(strings.Join(rettskrivarApprovers, ","))
+	p.DecidedAt = timePtr(now)
+
+	if bw := db.findBannedWord(p.WordID); bw != nil {
+		bw.UnbannedAt = timePtr(now)
+	}
+	return nil
+}
+
+// RejectUnbanProposal rejects a pending proposal, leaving the ban in place
+func (db *DB) RejectUnbanProposal(proposalID int, rejectorID string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	p := db.findUnbanProposal(proposalID)
+	if p == nil || p.Status != "pending" {
+		return fmt.Errorf("no pending unban proposal found for rejection")
+	}
+	p.Status = "rejected"
+	p.DecidedBy = stringPtr(rejectorID)
+	p.DecidedAt = timePtr(db.Now())
+	return nil
+}
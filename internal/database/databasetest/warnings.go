@@ -0,0 +1,126 @@
+package databasetest
+
+import (
+	"time"
+
+	"askeladden/internal/database"
+)
+
+// GetUserWarningMode returns database.WarningModePublic if the user hasn't chosen
+func (db *DB) GetUserWarningMode(userID string) (string, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if mode, ok := db.warningModes[userID]; ok {
+		return mode, nil
+	}
+	return database.WarningModePublic, nil
+}
+
+// SetUserWarningMode stores how a user wants to be warned
+func (db *DB) SetUserWarningMode(userID, mode string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.warningModes[userID] = mode
+	return nil
+}
+
+// AddBannedWordWarning records a warning sent for a banned word in a message
+func (db *DB) AddBannedWordWarning(userID string, wordID int, channelID, sourceMessageID, warningChannelID, warningMessageID string) (int64, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	now := db.Now()
+	db.nextWarningID++
+	db.warnings = append(db.warnings, &database.BannedWordWarning{
+		ID:               db.nextWarningID,
+		UserID:           userID,
+		WordID:           wordID,
+		ChannelID:        channelID,
+		SourceMessageID:  sourceMessageID,
+		WarningChannelID: warningChannelID,
+		WarningMessageID: warningMessageID,
+		HitCount:         1,
+		CreatedAt:        now,
+		LastHitAt:        now,
+	})
+	return int64(db.nextWarningID), nil
+}
+
+// GetRecentBannedWordWarning returns nil, nil if there is no warning within the duration
+func (db *DB) GetRecentBannedWordWarning(userID string, wordID int, within time.Duration) (*database.BannedWordWarning, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	since := db.Now().Add(-within)
+	var latest *database.BannedWordWarning
+	for _, w := range db.warnings {
+		if w.UserID == userID && w.WordID == wordID && !w.LastHitAt.Before(since) &&
+			(latest == nil || w.LastHitAt.After(latest.LastHitAt)) {
+			latest = w
+		}
+	}
+	if latest == nil {
+		return nil, nil
+	}
+	c := *latest
+	return &c, nil
+}
+
+// HasRecentWarningInChannel reports whether a warning was posted in a channel within the duration
+func (db *DB) HasRecentWarningInChannel(channelID string, within time.Duration) (bool, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	since := db.Now().Add(-within)
+	for _, w := range db.warnings {
+		if w.WarningChannelID == channelID && !w.CreatedAt.Before(since) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// RecordBannedWordWarningHit counts another use of the word on an existing warning
+func (db *DB) RecordBannedWordWarningHit(warningID int) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for _, w := range db.warnings {
+		if w.ID == warningID {
+			w.HitCount++
+			w.LastHitAt = db.Now()
+		}
+	}
+	return nil
+}
+
+// GetBannedWordWarningsForMessage returns the warnings sent for a message in insertion order
+func (db *DB) GetBannedWordWarningsForMessage(sourceMessageID string) ([]*database.BannedWordWarning, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	var warnings []*database.BannedWordWarning
+	for _, w := range db.warnings {
+		if w.SourceMessageID == sourceMessageID {
+			c := *w
+			warnings = append(warnings, &c)
+		}
+	}
+	return warnings, nil
+}
+
+// DeleteBannedWordWarning removes a warning
+func (db *DB) DeleteBannedWordWarning(warningID int) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for i, w := range db.warnings {
+		if w.ID == warningID {
+			db.warnings = append(db.warnings[:i], db.warnings[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Supported values for the database.driver config key
+const (
+	DriverMySQL  = "mysql"
+	DriverSQLite = "sqlite"
+)
+
+// dialect holds the few pieces of SQL that differ between MySQL and SQLite.
+// Queries are otherwise shared, so they must stick to the common subset
+// (?-placeholders, CURRENT_TIMESTAMP instead of NOW(), no multi-table DROP).
+type dialect struct {
+	name string
+}
+
+// autoIncrementPK returns the column definition for an auto-incrementing primary key
+func (d dialect) autoIncrementPK(column string) string {
+	if d.name == DriverSQLite {
+		return column + " INTEGER PRIMARY KEY AUTOINCREMENT"
+	}
+	return column + " INT AUTO_INCREMENT PRIMARY KEY"
+}
+
+// enumColumn returns a column definition restricted to the given values.
+// MySQL gets a native ENUM, SQLite gets TEXT with a CHECK constraint.
+func (d dialect) enumColumn(column, defaultValue string, values ...string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = "'" + v + "'"
+	}
+	list := strings.Join(quoted, ", ")
+
+	if d.name == DriverSQLite {
+		return fmt.Sprintf("%s TEXT DEFAULT '%s' CHECK (%s IN (%s))", column, defaultValue, column, list)
+	}
+	return fmt.Sprintf("%s ENUM(%s) DEFAULT '%s'", column, list, defaultValue)
+}
+
+// columnExistsQuery returns a query counting matching columns for (table, column)
+func (d dialect) columnExistsQuery() string {
+	if d.name == DriverSQLite {
+		return "SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?"
+	}
+	return "SELECT COUNT(*) FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = ? AND column_name = ?"
+}
+
+// indexExistsQuery returns a query counting matching indexes for (table, index)
+func (d dialect) indexExistsQuery() string {
+	if d.name == DriverSQLite {
+		return "SELECT COUNT(*) FROM sqlite_master WHERE type = 'index' AND tbl_name = ? AND name = ?"
+	}
+	return "SELECT COUNT(*) FROM information_schema.statistics WHERE table_schema = DATABASE() AND table_name = ? AND index_name = ?"
+}
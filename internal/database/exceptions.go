@@ -0,0 +1,82 @@
+package database
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// AllowedPhrase is a phrase or /regex/ where no banned word is reported
+type AllowedPhrase struct {
+	ID        int
+	Phrase    string
+	AddedBy   string
+	CreatedAt time.Time
+}
+
+// UpdateBannedWordExceptions replaces the exceptions for a banned word.
+// Exceptions are stored newline-separated, and an empty list clears them.
+func (db *DB) UpdateBannedWordExceptions(wordID int, exceptions []string) error {
+	log.Printf("Updating exceptions for banned word %d: %v", wordID, exceptions)
+	var value any
+	if len(exceptions) > 0 {
+		value = strings.Join(exceptions, "\n")
+	}
+	query := fmt.Sprintf("UPDATE %s SET exceptions = ? WHERE id = ?", db.bannedWordsTable)
+	result, err := db.conn.Exec(query, value, wordID)
+	if err != nil {
+		log.Printf("Failed to update exceptions for banned word %d: %v", wordID, err)
+		return err
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("no banned word found with ID %d", wordID)
+	}
+	return nil
+}
+
+// GetAllowedPhrases returns the global allowlist in alphabetical order
+func (db *DB) GetAllowedPhrases() ([]*AllowedPhrase, error) {
+	query := fmt.Sprintf("SELECT id, phrase, added_by, created_at FROM %s ORDER BY phrase", db.allowlistTable)
+	rows, err := db.conn.Query(query)
+	if err != nil {
+		log.Printf("Failed to get allowed phrases: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var phrases []*AllowedPhrase
+	for rows.Next() {
+		var p AllowedPhrase
+		if err := rows.Scan(&p.ID, &p.Phrase, &p.AddedBy, &p.CreatedAt); err != nil {
+			return nil, err
+		}
+		phrases = append(phrases, &p)
+	}
+	return phrases, rows.Err()
+}
+
+// AddAllowedPhrase adds a phrase to the global allowlist
+func (db *DB) AddAllowedPhrase(phrase, addedBy string) error {
+	log.Printf("Adding allowed phrase %q by %s", phrase, addedBy)
+	query := fmt.Sprintf("INSERT INTO %s (phrase, added_by, created_at) VALUES (?, ?, ?)", db.allowlistTable)
+	if _, err := db.conn.Exec(query, phrase, addedBy, time.Now().UTC()); err != nil {
+		log.Printf("Failed to add allowed phrase %q: %v", phrase, err)
+		return err
+	}
+	return nil
+}
+
+// RemoveAllowedPhrase removes a phrase from the global allowlist and reports
+// whether it was there
+func (db *DB) RemoveAllowedPhrase(phrase string) (bool, error) {
+	log.Printf("Removing allowed phrase %q", phrase)
+	query := fmt.Sprintf("DELETE FROM %s WHERE phrase = ?", db.allowlistTable)
+	result, err := db.conn.Exec(query, phrase)
+	if err != nil {
+		log.Printf("Failed to remove allowed phrase %q: %v", phrase, err)
+		return false, err
+	}
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
+}
@@ -0,0 +1,481 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+)
+
+// migration er ei nummerert, ordna skjemaendring. Kvar migrasjon vert køyrd
+// éin gong per tabellsuffiks og registrert i schema_migrations-tabellen.
+type migration struct {
+	version     int
+	description string
+	up          func(db *DB) error
+}
+
+// migrations inneheld alle skjemaendringar i den rekkjefølgja dei skal køyrast.
+// Nye migrasjonar skal alltid leggjast til på slutten med neste versjonsnummer,
+// og ein migrasjon som er teken i bruk skal aldri endrast.
+var migrations = []migration{
+	{
+		version:     1,
+		description: "create questions, banned words and starboard tables",
+		up:          (*DB).migrateBaseTables,
+	},
+	{
+		version:     2,
+		description: "add legacy columns to banned words table",
+		up:          (*DB).migrateLegacyBannedWordColumns,
+	},
+	{
+		version:     3,
+		description: "add patterns column to banned words table",
+		up:          (*DB).migrateBannedWordPatterns,
+	},
+	{
+		version:     4,
+		description: "add suggestions column to banned words table",
+		up:          (*DB).migrateBannedWordSuggestions,
+	},
+	{
+		version:     5,
+		description: "add rejection columns to banned words table",
+		up:          (*DB).migrateBannedWordRejection,
+	},
+	{
+		version:     6,
+		description: "create unban proposals table and add unbanned_at to banned words",
+		up:          (*DB).migrateUnbanProposals,
+	},
+	{
+		version:     7,
+		description: "create user warning preferences table",
+		up:          (*DB).migrateUserWarningPreferences,
+	},
+	{
+		version:     8,
+		description: "create banned word warnings table",
+		up:          (*DB).migrateBannedWordWarnings,
+	},
+	{
+		version:     9,
+		description: "create banned word hits table",
+		up:          (*DB).migrateBannedWordHits,
+	},
+	{
+		version:     10,
+		description: "add category and severity columns to banned words table",
+		up:          (*DB).migrateBannedWordCategory,
+	},
+	{
+		version:     11,
+		description: "add exceptions column to banned words table and create allowlist table",
+		up:          (*DB).migrateBannedWordExceptions,
+	},
+	{
+		version:     12,
+		description: "create question posts table",
+		up:          (*DB).migrateQuestionPosts,
+	},
+	{
+		version:     13,
+		description: "add answer thread to question posts",
+		up:          (*DB).migrateQuestionPostThreads,
+	},
+	{
+		version:     14,
+		description: "index banned word hits by message",
+		up:          (*DB).migrateBannedWordHitsByMessage,
+	},
+}
+
+// MigrationState describes a known migration and whether it has been applied.
+type MigrationState struct {
+	Version     int
+	Description string
+	AppliedAt   *time.Time
+}
+
+// Migrate applies all pending migrations in order and returns how many were applied.
+func (db *DB) Migrate() (int, error) {
+	log.Println("Running database migrations")
+
+	if err := db.createMigrationsTable(); err != nil {
+		return 0, err
+	}
+
+	applied, err := db.appliedMigrations()
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, m := range migrations {
+		if _, ok := applied[m.version]; ok {
+			continue
+		}
+
+		log.Printf("Applying migration %d: %s", m.version, m.description)
+		if err := m.up(db); err != nil {
+			return count, fmt.Errorf("migration %d (%s) failed: %w", m.version, m.description, err)
+		}
+
+		query := fmt.Sprintf("INSERT INTO %s (version, description, applied_at) VALUES (?, ?, ?)", db.migrationsTable)
+		if _, err := db.conn.Exec(query, m.version, m.description, time.Now().UTC()); err != nil {
+			return count, fmt.Errorf("failed to record migration %d: %w", m.version, err)
+		}
+		count++
+	}
+
+	log.Printf("Database migrations completed (%d applied)", count)
+	return count, nil
+}
+
+// MigrationStatus returns every known migration together with when it was applied.
+func (db *DB) MigrationStatus() ([]MigrationState, error) {
+	if err := db.createMigrationsTable(); err != nil {
+		return nil, err
+	}
+
+	applied, err := db.appliedMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	states := make([]MigrationState, 0, len(migrations))
+	for _, m := range migrations {
+		state := MigrationState{Version: m.version, Description: m.description}
+		if appliedAt, ok := applied[m.version]; ok {
+			at := appliedAt
+			state.AppliedAt = &at
+		}
+		states = append(states, state)
+	}
+	return states, nil
+}
+
+// createMigrationsTable creates the bookkeeping table if it doesn't exist
+func (db *DB) createMigrationsTable() error {
+	query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+		version INT PRIMARY KEY,
+		description VARCHAR(255) NOT NULL,
+		applied_at TIMESTAMP NOT NULL
+	);`, db.migrationsTable)
+
+	if _, err := db.conn.Exec(query); err != nil {
+		return fmt.Errorf("failed to create %s table: %w", db.migrationsTable, err)
+	}
+	return nil
+}
+
+// appliedMigrations returns the applied migration versions mapped to when they ran
+func (db *DB) appliedMigrations() (map[int]time.Time, error) {
+	query := fmt.Sprintf("SELECT version, applied_at FROM %s", db.migrationsTable)
+	rows, err := db.conn.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", db.migrationsTable, err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int]time.Time)
+	for rows.Next() {
+		var version int
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		applied[version] = appliedAt
+	}
+	return applied, rows.Err()
+}
+
+// execAll runs each statement in order and stops at the first error
+func (db *DB) execAll(statements ...string) error {
+	for _, statement := range statements {
+		if _, err := db.conn.Exec(statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// columnExists checks whether a column exists in one of our tables
+func (db *DB) columnExists(table, column string) (bool, error) {
+	var count int
+	if err := db.conn.QueryRow(db.dialect.columnExistsQuery(), table, column).Scan(&count); err != nil && err != sql.ErrNoRows {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// addColumnIfMissing adds a column unless an older schema already has it
+func (db *DB) addColumnIfMissing(table, column, definition string) error {
+	exists, err := db.columnExists(table, column)
+	if err != nil {
+		return fmt.Errorf("failed to check column %s.%s: %w", table, column, err)
+	}
+	if exists {
+		return nil
+	}
+
+	log.Printf("Adding %s column to %s table", column, table)
+	_, err = db.conn.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition))
+	return err
+}
+
+// createIndexIfMissing creates the index idx_<table>_<name> on columns unless
+// it exists. MySQL has no CREATE INDEX IF NOT EXISTS, so a migration that
+// stopped halfway would otherwise fail on the index every time it is re-run.
+func (db *DB) createIndexIfMissing(table, name, columns string) error {
+	index := fmt.Sprintf("idx_%s_%s", table, name)
+	var count int
+	if err := db.conn.QueryRow(db.dialect.indexExistsQuery(), table, index).Scan(&count); err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("failed to check index %s: %w", index, err)
+	}
+	if count > 0 {
+		return nil
+	}
+
+	_, err := db.conn.Exec(fmt.Sprintf("CREATE INDEX %s ON %s (%s)", index, table, columns))
+	return err
+}
+
+// migrateBaseTables creates the original tables. The statements use IF NOT EXISTS
+// so that databases created before schema_migrations existed are adopted as-is.
+func (db *DB) migrateBaseTables() error {
+	return db.execAll(
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+			%s,
+			question TEXT NOT NULL,
+			author_id VARCHAR(255) NOT NULL,
+			author_name VARCHAR(255) NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			times_asked INT DEFAULT 0,
+			last_asked_at TIMESTAMP NULL,
+			message_id VARCHAR(255),
+			channel_id VARCHAR(255),
+			%s,
+			approval_message_id VARCHAR(255),
+			approved_by VARCHAR(255),
+			approved_at TIMESTAMP NULL
+		);`, db.tableName, db.dialect.autoIncrementPK("id"),
+			db.dialect.enumColumn("approval_status", "pending", "pending", "approved", "rejected")),
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+			%s,
+			word VARCHAR(255) NOT NULL UNIQUE,
+			reason TEXT,
+			author_id VARCHAR(255) NOT NULL,
+			author_name VARCHAR(255) NOT NULL,
+			forum_thread_id VARCHAR(255),
+			original_message_id VARCHAR(255),
+			%s,
+			approval_message_id VARCHAR(255),
+			opplysar_approved_by VARCHAR(255),
+			opplysar_approved_at TIMESTAMP NULL,
+			rettskrivar_approved_by VARCHAR(255),
+			rettskrivar_approved_at TIMESTAMP NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);`, db.bannedWordsTable, db.dialect.autoIncrementPK("id"),
+			db.dialect.enumColumn("approval_status", "pending", "pending", "opplysar_approved", "fully_approved", "rejected")),
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+			%s,
+			original_message_id VARCHAR(255) NOT NULL UNIQUE,
+			starboard_message_id VARCHAR(255) NOT NULL,
+			channel_id VARCHAR(255) NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);`, db.starboardTable, db.dialect.autoIncrementPK("id")),
+	)
+}
+
+// migrateLegacyBannedWordColumns adds the columns that used to be added by hand
+// with the one-off programs in tools/ and the old runMigrations check.
+func (db *DB) migrateLegacyBannedWordColumns() error {
+	columns := []struct{ name, definition string }{
+		{"forum_thread_id", "VARCHAR(255) NULL"},
+		{"original_message_id", "VARCHAR(255) NULL"},
+		{"author_name", "VARCHAR(255) NOT NULL DEFAULT ''"},
+	}
+	for _, c := range columns {
+		if err := db.addColumnIfMissing(db.bannedWordsTable, c.name, c.definition); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateBannedWordPatterns adds the newline-separated phrase, inflection and
+// stem patterns that are matched in addition to the word itself.
+func (db *DB) migrateBannedWordPatterns() error {
+	return db.addColumnIfMissing(db.bannedWordsTable, "patterns", "TEXT NULL")
+}
+
+// migrateBannedWordSuggestions adds the newline-separated nynorsk alternatives
+// that the warning embed shows for each banned word.
+func (db *DB) migrateBannedWordSuggestions() error {
+	return db.addColumnIfMissing(db.bannedWordsTable, "suggestions", "TEXT NULL")
+}
+
+// migrateBannedWordRejection adds who rejected (or withdrew) a banned word,
+// when, and the optional reason they gave.
+func (db *DB) migrateBannedWordRejection() error {
+	columns := []struct{ name, definition string }{
+		{"rejected_by", "VARCHAR(255) NULL"},
+		{"rejected_at", "TIMESTAMP NULL"},
+		{"rejection_reason", "TEXT NULL"},
+	}
+	for _, c := range columns {
+		if err := db.addColumnIfMissing(db.bannedWordsTable, c.name, c.definition); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateUnbanProposals creates the table for proposals to lift a ban and marks
+// lifted bans on the banned word itself, so the history is kept.
+func (db *DB) migrateUnbanProposals() error {
+	if err := db.addColumnIfMissing(db.bannedWordsTable, "unbanned_at", "TIMESTAMP NULL"); err != nil {
+		return err
+	}
+	return db.execAll(fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+		%s,
+		word_id INT NOT NULL,
+		proposer_id VARCHAR(255) NOT NULL,
+		proposer_name VARCHAR(255) NOT NULL,
+		reason TEXT,
+		%s,
+		approval_message_id VARCHAR(255),
+		opplysar_approved_by VARCHAR(255),
+		rettskrivar_approved_by VARCHAR(255),
+		decided_by VARCHAR(255),
+		decided_at TIMESTAMP NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	);`, db.unbanTable, db.dialect.autoIncrementPK("id"),
+		db.dialect.enumColumn("status", "pending", "pending", "approved", "rejected")))
+}
+
+// migrateUserWarningPreferences creates the table where users choose how they
+// get banned word warnings.
+func (db *DB) migrateUserWarningPreferences() error {
+	return db.execAll(fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+		user_id VARCHAR(255) PRIMARY KEY,
+		%s,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	);`, db.warningPrefsTable,
+		db.dialect.enumColumn("mode", WarningModePublic, WarningModePublic, WarningModeDM, WarningModeOff)))
+}
+
+// migrateBannedWordWarnings creates the log of sent warnings, one row per word,
+// used for cooldowns and to find the warning that belongs to a message.
+func (db *DB) migrateBannedWordWarnings() error {
+	err := db.execAll(
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+			%s,
+			user_id VARCHAR(255) NOT NULL,
+			word_id INT NOT NULL,
+			channel_id VARCHAR(255) NOT NULL,
+			source_message_id VARCHAR(255) NOT NULL,
+			warning_channel_id VARCHAR(255) NOT NULL,
+			warning_message_id VARCHAR(255) NOT NULL,
+			hit_count INT NOT NULL DEFAULT 1,
+			created_at TIMESTAMP NOT NULL,
+			last_hit_at TIMESTAMP NOT NULL
+		);`, db.warningsTable, db.dialect.autoIncrementPK("id")),
+	)
+	if err != nil {
+		return err
+	}
+	if err := db.createIndexIfMissing(db.warningsTable, "user_word", "user_id, word_id"); err != nil {
+		return err
+	}
+	return db.createIndexIfMissing(db.warningsTable, "warning_channel", "warning_channel_id")
+}
+
+// migrateBannedWordHits creates the log of every detected banned word, used
+// for statistics.
+func (db *DB) migrateBannedWordHits() error {
+	err := db.execAll(
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+			%s,
+			word_id INT NOT NULL,
+			user_id VARCHAR(255) NOT NULL,
+			channel_id VARCHAR(255) NOT NULL,
+			message_id VARCHAR(255) NOT NULL,
+			created_at TIMESTAMP NOT NULL
+		);`, db.hitsTable, db.dialect.autoIncrementPK("id")),
+	)
+	if err != nil {
+		return err
+	}
+	if err := db.createIndexIfMissing(db.hitsTable, "created", "created_at"); err != nil {
+		return err
+	}
+	return db.createIndexIfMissing(db.hitsTable, "user_created", "user_id, created_at")
+}
+
+// migrateBannedWordCategory adds the category and severity of a banned word.
+// Existing words become medium severity bokmål forms, which is how they were
+// treated before.
+func (db *DB) migrateBannedWordCategory() error {
+	columns := []struct{ name, definition string }{
+		{"category", "VARCHAR(32) NOT NULL DEFAULT 'bokmal'"},
+		{"severity", "VARCHAR(16) NOT NULL DEFAULT 'medium'"},
+	}
+	for _, c := range columns {
+		if err := db.addColumnIfMissing(db.bannedWordsTable, c.name, c.definition); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateBannedWordExceptions adds the newline-separated phrases and regexes
+// where a banned word is allowed, and the allowlist that applies to every word.
+func (db *DB) migrateBannedWordExceptions() error {
+	if err := db.addColumnIfMissing(db.bannedWordsTable, "exceptions", "TEXT NULL"); err != nil {
+		return err
+	}
+	return db.execAll(fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+		%s,
+		phrase VARCHAR(255) NOT NULL UNIQUE,
+		added_by VARCHAR(255) NOT NULL,
+		created_at TIMESTAMP NOT NULL
+	);`, db.allowlistTable, db.dialect.autoIncrementPK("id")))
+}
+
+// migrateQuestionPosts creates the history of posted daily questions, one row
+// per post, so we know when and where each question ran and what triggered it.
+func (db *DB) migrateQuestionPosts() error {
+	err := db.execAll(
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+			%s,
+			question_id INT NOT NULL,
+			message_id VARCHAR(255) NOT NULL,
+			channel_id VARCHAR(255) NOT NULL,
+			trigger_reason VARCHAR(32) NOT NULL,
+			triggered_by VARCHAR(255) NULL,
+			posted_at TIMESTAMP NOT NULL
+		);`, db.questionPostsTable, db.dialect.autoIncrementPK("id")),
+	)
+	if err != nil {
+		return err
+	}
+	if err := db.createIndexIfMissing(db.questionPostsTable, "posted", "posted_at"); err != nil {
+		return err
+	}
+	return db.createIndexIfMissing(db.questionPostsTable, "question", "question_id")
+}
+
+// migrateQuestionPostThreads records the answer thread started on a posted
+// question, if any
+func (db *DB) migrateQuestionPostThreads() error {
+	return db.addColumnIfMissing(db.questionPostsTable, "thread_id", "VARCHAR(255) NULL")
+}
+
+// migrateBannedWordHitsByMessage lets an edited message look up which words it
+// was already counted for
+func (db *DB) migrateBannedWordHitsByMessage() error {
+	return db.createIndexIfMissing(db.hitsTable, "message", "message_id")
+}
@@ -0,0 +1,102 @@
+package database
+
+import (
+	"testing"
+)
+
+// migrationCounts returns how many times each version is recorded as applied
+func migrationCounts(t *testing.T, db *DB) map[int]int {
+	t.Helper()
+	rows, err := db.conn.Query("SELECT version, COUNT(*) FROM " + db.migrationsTable + " GROUP BY version")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	counts := make(map[int]int)
+	for rows.Next() {
+		var version, count int
+		if err := rows.Scan(&version, &count); err != nil {
+			t.Fatal(err)
+		}
+		counts[version] = count
+	}
+	return counts
+}
+
+func TestMigrationsAreRecordedOncePerSuffix(t *testing.T) {
+	cfg := sqliteConfig(t, "_testing")
+	newTestDB(t, cfg).Close()
+	db := newTestDB(t, cfg)
+
+	if db.migrationsTable != "schema_migrations_testing" {
+		t.Fatalf("migrations table = %s", db.migrationsTable)
+	}
+	counts := migrationCounts(t, db)
+	if len(counts) != len(migrations) {
+		t.Errorf("%d versions recorded, want %d: %v", len(counts), len(migrations), counts)
+	}
+	for version := 1; version <= len(migrations); version++ {
+		if counts[version] != 1 {
+			t.Errorf("version %d recorded %d times, want once", version, counts[version])
+		}
+	}
+
+	for _, table := range []string{db.tableName, db.bannedWordsTable, db.hitsTable, db.questionPostsTable} {
+		var n int
+		db.conn.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?", table).Scan(&n)
+		if n != 1 {
+			t.Errorf("table %s missing", table)
+		}
+	}
+	var unsuffixed int
+	db.conn.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name IN ('daily_questions', 'schema_migrations')").Scan(&unsuffixed)
+	if unsuffixed != 0 {
+		t.Error("tables without the suffix were created")
+	}
+}
+
+func TestMigrationStatusBeforeAndAfterMigrate(t *testing.T) {
+	db, err := Open(sqliteConfig(t, ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	states, err := db.MigrationStatus()
+	if err != nil || len(states) != len(migrations) {
+		t.Fatalf("MigrationStatus() = %d states, %v", len(states), err)
+	}
+	for _, s := range states {
+		if s.AppliedAt != nil {
+			t.Errorf("migration %d applied before Migrate", s.Version)
+		}
+	}
+
+	if applied, err := db.Migrate(); err != nil || applied != len(migrations) {
+		t.Fatalf("Migrate() = %d, %v", applied, err)
+	}
+	states, _ = db.MigrationStatus()
+	for i, s := range states {
+		if s.Version != i+1 || s.AppliedAt == nil {
+			t.Errorf("state %d = %+v", i, s)
+		}
+	}
+}
+
+func TestHalfAppliedMigrationsRerun(t *testing.T) {
+	db := newTestDB(t, sqliteConfig(t, ""))
+
+	// The tables and indexes exist, but the migrations were not recorded
+	if _, err := db.conn.Exec("DELETE FROM " + db.migrationsTable + " WHERE version IN (8, 9, 12, 14)"); err != nil {
+		t.Fatal(err)
+	}
+
+	applied, err := db.Migrate()
+	if err != nil || applied != 4 {
+		t.Fatalf("Migrate() = %d, %v, want 4 re-run", applied, err)
+	}
+	if counts := migrationCounts(t, db); counts[8] != 1 || counts[14] != 1 {
+		t.Errorf("re-run migrations recorded %v", counts)
+	}
+}
@@ -0,0 +1,82 @@
+package database
+
+import (
+	"fmt"
+	"log"
+	"time"
+)
+
+// What made a daily question be posted
+const (
+	QuestionTriggerMorning    = "morning"    // The morning schedule
+	QuestionTriggerInactivity = "inactivity" // The default channel had been quiet
+	QuestionTriggerPoke       = "poke"       // An admin used !poke
+)
+
+// QuestionPost is one posting of a daily question
+type QuestionPost struct {
+	ID          int
+	QuestionID  int
+	Question    string // The question text, from the questions table
+	MessageID   string
+	ThreadID    *string // The answer thread started on the message
+	ChannelID   string
+	Trigger     string  // One of the QuestionTrigger constants
+	TriggeredBy *string // The admin behind a !poke
+	PostedAt    time.Time
+}
+
+// AddQuestionPost records that a daily question was posted. threadID is empty
+// if no answer thread was started, and triggeredBy is empty for scheduled posts.
+func (db *DB) AddQuestionPost(questionID int, messageID, threadID, channelID, trigger, triggeredBy string) (int64, error) {
+	query := fmt.Sprintf("INSERT INTO %s (question_id, message_id, thread_id, channel_id, trigger_reason, triggered_by, posted_at) VALUES (?, ?, ?, ?, ?, ?, ?)", db.questionPostsTable)
+	result, err := db.conn.Exec(query, questionID, messageID, nullString(threadID), channelID, trigger, nullString(triggeredBy), time.Now().UTC())
+	if err != nil {
+		log.Printf("[DATABASE] Failed to add question post for question ID %d: %v", questionID, err)
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// GetQuestionPosts returns posted daily questions, newest first
+func (db *DB) GetQuestionPosts(offset, limit int) ([]*QuestionPost, error) {
+	query := fmt.Sprintf(`SELECT p.id, p.question_id, COALESCE(q.question, ''), p.message_id, p.thread_id, p.channel_id, p.trigger_reason, p.triggered_by, p.posted_at
+		FROM %s p LEFT JOIN %s q ON q.id = p.question_id
+		ORDER BY p.posted_at DESC, p.id DESC
+		LIMIT ? OFFSET ?`, db.questionPostsTable, db.tableName)
+	rows, err := db.conn.Query(query, limit, offset)
+	if err != nil {
+		log.Printf("[DATABASE] Failed to get question posts: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var posts []*QuestionPost
+	for rows.Next() {
+		var p QuestionPost
+		if err := rows.Scan(&p.ID, &p.QuestionID, &p.Question, &p.MessageID, &p.ThreadID, &p.ChannelID, &p.Trigger, &p.TriggeredBy, &p.PostedAt); err != nil {
+			return nil, err
+		}
+		posts = append(posts, &p)
+	}
+	return posts, rows.Err()
+}
+
+// CountQuestionPosts returns how many daily questions have been posted
+func (db *DB) CountQuestionPosts() (int, error) {
+	var count int
+	query := fmt.Sprintf("SELECT COUNT(*) FROM %s", db.questionPostsTable)
+	if err := db.conn.QueryRow(query).Scan(&count); err != nil {
+		log.Printf("[DATABASE] Failed to count question posts: %v", err)
+		return 0, err
+	}
+	return count, nil
+}
+
+// nullString returns s, or nil for the empty string so it is stored as NULL
+func nullString(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
@@ -0,0 +1,100 @@
+package database
+
+import (
+	"fmt"
+	"log"
+	"time"
+)
+
+// BannedWordHitCount is how many times a banned word was used
+type BannedWordHitCount struct {
+	WordID int
+	Word   string
+	Count  int
+}
+
+// AddBannedWordHit logs a detected banned word. Every use is logged, also
+// when no warning is sent, so the statistics show what is actually written.
+func (db *DB) AddBannedWordHit(wordID int, userID, channelID, messageID string) error {
+	query := fmt.Sprintf("INSERT INTO %s (word_id, user_id, channel_id, message_id, created_at) VALUES (?, ?, ?, ?, ?)", db.hitsTable)
+	_, err := db.conn.Exec(query, wordID, userID, channelID, messageID, time.Now().UTC())
+	if err != nil {
+		log.Printf("Failed to add banned word hit: %v", err)
+	}
+	return err
+}
+
+// GetBannedWordHitWordIDs returns the IDs of the banned words logged for a
+// message, i.e. the words it has already been counted for
+func (db *DB) GetBannedWordHitWordIDs(messageID string) ([]int, error) {
+	query := fmt.Sprintf("SELECT DISTINCT word_id FROM %s WHERE message_id = ?", db.hitsTable)
+	rows, err := db.conn.Query(query, messageID)
+	if err != nil {
+		log.Printf("Failed to get banned word hits for message %s: %v", messageID, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var wordIDs []int
+	for rows.Next() {
+		var wordID int
+		if err := rows.Scan(&wordID); err != nil {
+			return nil, err
+		}
+		wordIDs = append(wordIDs, wordID)
+	}
+	return wordIDs, rows.Err()
+}
+
+// GetTopBannedWords returns the most used banned words since the given time,
+// for one user or for everyone if userID is empty
+func (db *DB) GetTopBannedWords(userID string, since time.Time, limit int) ([]BannedWordHitCount, error) {
+	where := "h.created_at >= ?"
+	args := []any{since.UTC()}
+	if userID != "" {
+		where += " AND h.user_id = ?"
+		args = append(args, userID)
+	}
+	args = append(args, limit)
+
+	query := fmt.Sprintf(`SELECT h.word_id, w.word, COUNT(*) AS hits
+		FROM %s h JOIN %s w ON w.id = h.word_id
+		WHERE %s
+		GROUP BY h.word_id, w.word
+		ORDER BY hits DESC, w.word
+		LIMIT ?`, db.hitsTable, db.bannedWordsTable, where)
+	rows, err := db.conn.Query(query, args...)
+	if err != nil {
+		log.Printf("Failed to get top banned words: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var counts []BannedWordHitCount
+	for rows.Next() {
+		var c BannedWordHitCount
+		if err := rows.Scan(&c.WordID, &c.Word, &c.Count); err != nil {
+			return nil, err
+		}
+		counts = append(counts, c)
+	}
+	return counts, rows.Err()
+}
+
+// CountBannedWordHits counts hits from (inclusive) to (exclusive), for one
+// user or for everyone if userID is empty
+func (db *DB) CountBannedWordHits(userID string, from, to time.Time) (int, error) {
+	query := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE created_at >= ? AND created_at < ?", db.hitsTable)
+	args := []any{from.UTC(), to.UTC()}
+	if userID != "" {
+		query += " AND user_id = ?"
+		args = append(args, userID)
+	}
+
+	var count int
+	if err := db.conn.QueryRow(query, args...).Scan(&count); err != nil {
+		log.Printf("Failed to count banned word hits: %v", err)
+		return 0, err
+	}
+	return count, nil
+}
@@ -0,0 +1,142 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// UnbanProposal is a proposal to lift the ban on a fully approved banned word.
+// It needs the same combined opplysar and rettskrivar approval as a ban.
+type UnbanProposal struct {
+	ID                    int
+	WordID                int
+	ProposerID            string
+	ProposerName          string
+	Reason                string
+	Status                string // pending, approved or rejected
+	ApprovalMessageID     *string
+	OpplysarApprovedBy    *string
+	RettskrivarApprovedBy *string
+	DecidedBy             *string // Rejector; empty for approvals
+	DecidedAt             *time.Time
+	CreatedAt             time.Time
+}
+
+// unbanProposalColumns is the column list scanned by scanUnbanProposal
+const unbanProposalColumns = "id, word_id, proposer_id, proposer_name, reason, status, approval_message_id, opplysar_approved_by, rettskrivar_approved_by, decided_by, decided_at, created_at"
+
+// scanUnbanProposal scans a row selected with unbanProposalColumns
+func scanUnbanProposal(row rowScanner) (*UnbanProposal, error) {
+	var p UnbanProposal
+	var reason sql.NullString
+	err := row.Scan(&p.ID, &p.WordID, &p.ProposerID, &p.ProposerName, &reason, &p.Status,
+		&p.ApprovalMessageID, &p.OpplysarApprovedBy, &p.RettskrivarApprovedBy, &p.DecidedBy, &p.DecidedAt, &p.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	p.Reason = reason.String
+	return &p, nil
+}
+
+// AddUnbanProposal adds a pending proposal to lift the ban on a word
+func (db *DB) AddUnbanProposal(wordID int, proposerID, proposerName, reason string) (int64, error) {
+	log.Printf("Adding unban proposal for banned word %d by %s", wordID, proposerID)
+	query := fmt.Sprintf("INSERT INTO %s (word_id, proposer_id, proposer_name, reason, status) VALUES (?, ?, ?, ?, 'pending')", db.unbanTable)
+	result, err := db.conn.Exec(query, wordID, proposerID, proposerName, reason)
+	if err != nil {
+		log.Printf("Failed to add unban proposal: %v", err)
+		return 0, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		log.Printf("Failed to get last insert ID for unban proposal: %v", err)
+		return 0, err
+	}
+	log.Printf("Successfully added unban proposal with ID %d", id)
+	return id, nil
+}
+
+// UpdateUnbanProposalApprovalMessageID updates the retting channel message for a proposal
+func (db *DB) UpdateUnbanProposalApprovalMessageID(proposalID int, approvalMessageID string) error {
+	query := fmt.Sprintf("UPDATE %s SET approval_message_id = ? WHERE id = ?", db.unbanTable)
+	_, err := db.conn.Exec(query, approvalMessageID, proposalID)
+	if err != nil {
+		log.Printf("Failed to update approval message ID for unban proposal %d: %v", proposalID, err)
+	}
+	return err
+}
+
+// GetUnbanProposalByID gets an unban proposal by its ID
+func (db *DB) GetUnbanProposalByID(proposalID int) (*UnbanProposal, error) {
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE id = ?", unbanProposalColumns, db.unbanTable)
+	return scanUnbanProposal(db.conn.QueryRow(query, proposalID))
+}
+
+// GetUnbanProposalByApprovalMessageID gets an unban proposal by its retting channel message
+func (db *DB) GetUnbanProposalByApprovalMessageID(approvalMessageID string) (*UnbanProposal, error) {
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE approval_message_id = ?", unbanProposalColumns, db.unbanTable)
+	return scanUnbanProposal(db.conn.QueryRow(query, approvalMessageID))
+}
+
+// GetPendingUnbanProposalForWord returns the pending proposal for a word, or nil if there is none
+func (db *DB) GetPendingUnbanProposalForWord(wordID int) (*UnbanProposal, error) {
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE word_id = ? AND status = 'pending' ORDER BY created_at ASC LIMIT 1", unbanProposalColumns, db.unbanTable)
+	p, err := scanUnbanProposal(db.conn.QueryRow(query, wordID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return p, err
+}
+
+// ApproveUnbanProposal approves a pending proposal and lifts the ban on its word.
+// The banned word row is kept with unbanned_at set.
+func (db *DB) ApproveUnbanProposal(proposalID int, opplysarApprovers, rettskrivarApprovers []string) error {
+	log.Printf("Combined approval for unban proposal %d", proposalID)
+
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := fmt.Sprintf("UPDATE %s SET status = 'approved', opplysar_approved_by = ?, rettskrivar_approved_by = ?, decided_at = CURRENT_TIMESTAMP WHERE id = ? AND status = 'pending'", db.unbanTable)
+	result, err := tx.Exec(query, strings.Join(opplysarApprovers, ","), strings.Join(rettskrivarApprovers, ","), proposalID)
+	if err != nil {
+		log.Printf("Failed to approve unban proposal %d: %v", proposalID, err)
+		return err
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("no pending unban proposal found for approval")
+	}
+
+	query = fmt.Sprintf("UPDATE %s SET unbanned_at = CURRENT_TIMESTAMP WHERE id = (SELECT word_id FROM %s WHERE id = ?)", db.bannedWordsTable, db.unbanTable)
+	if _, err := tx.Exec(query, proposalID); err != nil {
+		log.Printf("Failed to lift ban for unban proposal %d: %v", proposalID, err)
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	log.Printf("Successfully approved unban proposal %d", proposalID)
+	return nil
+}
+
+// RejectUnbanProposal rejects a pending proposal, leaving the ban in place
+func (db *DB) RejectUnbanProposal(proposalID int, rejectorID string) error {
+	log.Printf("Rejecting unban proposal %d by %s", proposalID, rejectorID)
+	query := fmt.Sprintf("UPDATE %s SET status = 'rejected', decided_by = ?, decided_at = CURRENT_TIMESTAMP WHERE id = ? AND status = 'pending'", db.unbanTable)
+	result, err := db.conn.Exec(query, rejectorID, proposalID)
+	if err != nil {
+		log.Printf("Failed to reject unban proposal %d: %v", proposalID, err)
+		return err
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("no pending unban proposal found for rejection")
+	}
+	log.Printf("Successfully rejected unban proposal %d", proposalID)
+	return nil
+}
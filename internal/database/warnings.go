@@ -0,0 +1,167 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+)
+
+// How a user wants to get banned word warnings
+const (
+	WarningModePublic = "public" // Reply to the message in the channel (default)
+	WarningModeDM     = "dm"     // Send the warning as a direct message
+	WarningModeOff    = "off"    // No warnings at all
+)
+
+// BannedWordWarning is a warning sent for one banned word in a message. Later
+// uses of the same word within the cooldown are counted on it instead of
+// getting a new warning.
+type BannedWordWarning struct {
+	ID               int
+	UserID           string
+	WordID           int
+	ChannelID        string // Channel of the message that used the word
+	SourceMessageID  string
+	WarningChannelID string // Where the warning was sent: the same channel, or a DM
+	WarningMessageID string
+	HitCount         int
+	CreatedAt        time.Time
+	LastHitAt        time.Time
+}
+
+// bannedWordWarningColumns is the column list scanned by scanBannedWordWarning
+const bannedWordWarningColumns = "id, user_id, word_id, channel_id, source_message_id, warning_channel_id, warning_message_id, hit_count, created_at, last_hit_at"
+
+// scanBannedWordWarning scans a row selected with bannedWordWarningColumns
+func scanBannedWordWarning(row rowScanner) (*BannedWordWarning, error) {
+	var w BannedWordWarning
+	err := row.Scan(&w.ID, &w.UserID, &w.WordID, &w.ChannelID, &w.SourceMessageID,
+		&w.WarningChannelID, &w.WarningMessageID, &w.HitCount, &w.CreatedAt, &w.LastHitAt)
+	if err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+// GetUserWarningMode returns how a user wants to be warned, WarningModePublic if they haven't chosen
+func (db *DB) GetUserWarningMode(userID string) (string, error) {
+	var mode string
+	query := fmt.Sprintf("SELECT mode FROM %s WHERE user_id = ?", db.warningPrefsTable)
+	err := db.conn.QueryRow(query, userID).Scan(&mode)
+	if err == sql.ErrNoRows {
+		return WarningModePublic, nil
+	}
+	if err != nil {
+		log.Printf("Failed to get warning mode for user %s: %v", userID, err)
+		return WarningModePublic, err
+	}
+	return mode, nil
+}
+
+// SetUserWarningMode stores how a user wants to be warned
+func (db *DB) SetUserWarningMode(userID, mode string) error {
+	log.Printf("Setting warning mode for user %s to %s", userID, mode)
+
+	// Check for an existing row instead of relying on an upsert, since MySQL
+	// and SQLite have different syntax for it
+	var count int
+	query := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE user_id = ?", db.warningPrefsTable)
+	if err := db.conn.QueryRow(query, userID).Scan(&count); err != nil {
+		log.Printf("Failed to check warning mode: %v", err)
+		return err
+	}
+
+	if count > 0 {
+		query = fmt.Sprintf("UPDATE %s SET mode = ?, updated_at = CURRENT_TIMESTAMP WHERE user_id = ?", db.warningPrefsTable)
+	} else {
+		query = fmt.Sprintf("INSERT INTO %s (mode, user_id) VALUES (?, ?)", db.warningPrefsTable)
+	}
+	if _, err := db.conn.Exec(query, mode, userID); err != nil {
+		log.Printf("Failed to store warning mode: %v", err)
+		return err
+	}
+	return nil
+}
+
+// AddBannedWordWarning records a warning sent for a banned word in a message.
+// Timestamps are set here in UTC rather than with CURRENT_TIMESTAMP, so the
+// cooldown comparisons work the same in MySQL and SQLite.
+func (db *DB) AddBannedWordWarning(userID string, wordID int, channelID, sourceMessageID, warningChannelID, warningMessageID string) (int64, error) {
+	now := time.Now().UTC()
+	query := fmt.Sprintf("INSERT INTO %s (user_id, word_id, channel_id, source_message_id, warning_channel_id, warning_message_id, hit_count, created_at, last_hit_at) VALUES (?, ?, ?, ?, ?, ?, 1, ?, ?)", db.warningsTable)
+	result, err := db.conn.Exec(query, userID, wordID, channelID, sourceMessageID, warningChannelID, warningMessageID, now, now)
+	if err != nil {
+		log.Printf("Failed to add banned word warning: %v", err)
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// GetRecentBannedWordWarning returns the latest warning for a user and word
+// that was sent or hit within the given duration, or nil if there is none
+func (db *DB) GetRecentBannedWordWarning(userID string, wordID int, within time.Duration) (*BannedWordWarning, error) {
+	since := time.Now().UTC().Add(-within)
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE user_id = ? AND word_id = ? AND last_hit_at >= ? ORDER BY last_hit_at DESC LIMIT 1", bannedWordWarningColumns, db.warningsTable)
+	w, err := scanBannedWordWarning(db.conn.QueryRow(query, userID, wordID, since))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		log.Printf("Failed to get recent banned word warning: %v", err)
+	}
+	return w, err
+}
+
+// HasRecentWarningInChannel reports whether a warning was posted in a channel within the given duration
+func (db *DB) HasRecentWarningInChannel(channelID string, within time.Duration) (bool, error) {
+	since := time.Now().UTC().Add(-within)
+	var count int
+	query := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE warning_channel_id = ? AND created_at >= ?", db.warningsTable)
+	if err := db.conn.QueryRow(query, channelID, since).Scan(&count); err != nil {
+		log.Printf("Failed to check recent warnings in channel %s: %v", channelID, err)
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// RecordBannedWordWarningHit counts another use of the word on an existing warning
+func (db *DB) RecordBannedWordWarningHit(warningID int) error {
+	query := fmt.Sprintf("UPDATE %s SET hit_count = hit_count + 1, last_hit_at = ? WHERE id = ?", db.warningsTable)
+	_, err := db.conn.Exec(query, time.Now().UTC(), warningID)
+	if err != nil {
+		log.Printf("Failed to record hit on banned word warning %d: %v", warningID, err)
+	}
+	return err
+}
+
+// GetBannedWordWarningsForMessage returns the warnings sent for a message, one per word
+func (db *DB) GetBannedWordWarningsForMessage(sourceMessageID string) ([]*BannedWordWarning, error) {
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE source_message_id = ? ORDER BY id", bannedWordWarningColumns, db.warningsTable)
+	rows, err := db.conn.Query(query, sourceMessageID)
+	if err != nil {
+		log.Printf("Failed to get warnings for message %s: %v", sourceMessageID, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var warnings []*BannedWordWarning
+	for rows.Next() {
+		w, err := scanBannedWordWarning(rows)
+		if err != nil {
+			return nil, err
+		}
+		warnings = append(warnings, w)
+	}
+	return warnings, rows.Err()
+}
+
+// DeleteBannedWordWarning removes a warning, e.g. when the word was edited out of the message
+func (db *DB) DeleteBannedWordWarning(warningID int) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = ?", db.warningsTable)
+	_, err := db.conn.Exec(query, warningID)
+	if err != nil {
+		log.Printf("Failed to delete banned word warning %d: %v", warningID, err)
+	}
+	return err
+}
@@ -0,0 +1,140 @@
+// Package dictionary slår opp ord i lokale ordlister, slik at godkjennarar kan
+// sjå om eit rapportert ord finst på nynorsk eller berre på bokmål. Listene kan
+// vere ei enkel liste med eitt ord per linje, eller ei fullformsliste frå Norsk
+// Ordbank, der kvar bøyingsform står i tredje kolonne.
+package dictionary
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Status is what the dictionary knows about a word
+type Status int
+
+const (
+	Unknown    Status = iota // In neither list, or no lists are loaded
+	Nynorsk                  // In the nynorsk list
+	BokmalOnly               // In the bokmål list, but not the nynorsk one
+)
+
+// String returns the status for logging
+func (s Status) String() string {
+	switch s {
+	case Nynorsk:
+		return "nynorsk"
+	case BokmalOnly:
+		return "bokmal only"
+	default:
+		return "unknown"
+	}
+}
+
+// Dictionary is an in-memory set of nynorsk and bokmål word forms. It is never
+// changed after loading and is safe for concurrent use. A nil Dictionary knows
+// no words.
+type Dictionary struct {
+	nynorsk map[string]struct{}
+	bokmal  map[string]struct{}
+}
+
+// New creates a dictionary from lists of word forms
+func New(nynorsk, bokmal []string) *Dictionary {
+	d := &Dictionary{nynorsk: make(map[string]struct{}), bokmal: make(map[string]struct{})}
+	for _, word := range nynorsk {
+		d.nynorsk[strings.ToLower(word)] = struct{}{}
+	}
+	for _, word := range bokmal {
+		d.bokmal[strings.ToLower(word)] = struct{}{}
+	}
+	return d
+}
+
+// Load reads the word lists. Either path may be empty to leave that list out.
+func Load(nynorskPath, bokmalPath string) (*Dictionary, error) {
+	d := &Dictionary{}
+	var err error
+	if d.nynorsk, err = loadFile(nynorskPath); err != nil {
+		return nil, err
+	}
+	if d.bokmal, err = loadFile(bokmalPath); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// loadFile reads a word list, or returns an empty set if path is empty
+func loadFile(path string) (map[string]struct{}, error) {
+	words := make(map[string]struct{})
+	if path == "" {
+		return words, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open word list: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if word, ok := parseLine(scanner.Text()); ok {
+			words[word] = struct{}{}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read word list %s: %w", path, err)
+	}
+	return words, nil
+}
+
+// parseLine returns the word form on a line of a word list. Empty lines,
+// #-comments and the header of a full-form list are skipped.
+func parseLine(line string) (string, bool) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return "", false
+	}
+
+	// Norsk Ordbank: LOEPENR, LEMMA_ID, OPPSLAG, TAG, ...
+	if fields := strings.Split(line, "\t"); len(fields) > 1 {
+		if len(fields) < 3 {
+			return "", false
+		}
+		if _, err := strconv.Atoi(fields[0]); err != nil {
+			return "", false
+		}
+		line = strings.TrimSpace(fields[2])
+	}
+	if line == "" {
+		return "", false
+	}
+	return strings.ToLower(line), true
+}
+
+// Lookup reports whether a word form is nynorsk, only bokmål, or unknown,
+// ignoring case
+func (d *Dictionary) Lookup(word string) Status {
+	if d == nil {
+		return Unknown
+	}
+	word = strings.ToLower(strings.TrimSpace(word))
+	if _, ok := d.nynorsk[word]; ok {
+		return Nynorsk
+	}
+	if _, ok := d.bokmal[word]; ok {
+		return BokmalOnly
+	}
+	return Unknown
+}
+
+// Size returns the number of word forms in the nynorsk and bokmål lists
+func (d *Dictionary) Size() (nynorsk, bokmal int) {
+	if d == nil {
+		return 0, 0
+	}
+	return len(d.nynorsk), len(d.bokmal)
+}
@@ -0,0 +1,72 @@
+package dictionary
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeList writes a word list to a temporary file and returns its path
+func writeList(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "ordliste.txt")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLookup(t *testing.T) {
+	nynorsk := writeList(t, "# Nynorsk\nikkje\nKorleis\n\nblåbær\n")
+	bokmal := writeList(t, "LOEPENR\tLEMMA_ID\tOPPSLAG\tTAG\n"+
+		"1\t10\tikke\tadv\n"+
+		"2\t11\thvordan\tadv\n"+
+		"3\t12\tblåbær\tsubst\n")
+
+	d, err := Load(nynorsk, bokmal)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]Status{
+		"ikkje":   Nynorsk,
+		"korleis": Nynorsk,
+		"IKKE":    BokmalOnly,
+		"hvordan": BokmalOnly,
+		"blåbær":  Nynorsk,
+		"oppslag": Unknown,
+		"xyz":     Unknown,
+	}
+	for word, want := range tests {
+		if got := d.Lookup(word); got != want {
+			t.Errorf("Lookup(%q) = %v, want %v", word, got, want)
+		}
+	}
+
+	if n, b := d.Size(); n != 3 || b != 3 {
+		t.Errorf("Size() = %d, %d, want 3, 3", n, b)
+	}
+}
+
+func TestLoadWithoutBokmal(t *testing.T) {
+	d, err := Load(writeList(t, "ikkje\n"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := d.Lookup("ikke"); got != Unknown {
+		t.Errorf("Lookup(ikke) = %v without a bokmål list, want unknown", got)
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	if _, err := Load(filepath.Join(t.TempDir(), "finst-ikkje.txt"), ""); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+func TestNilDictionary(t *testing.T) {
+	var d *Dictionary
+	if got := d.Lookup("ikkje"); got != Unknown {
+		t.Errorf("nil Lookup = %v", got)
+	}
+}
@@ -0,0 +1,77 @@
+package questions
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"askeladden/internal/config"
+	"askeladden/internal/database"
+)
+
+// Source is the part of the database questions are picked from
+type Source interface {
+	GetApprovedQuestions() ([]*database.Question, error)
+}
+
+// FromConfig returns the strategy set in the scheduler config. An unknown
+// strategy is logged and gives least asked first.
+func FromConfig(cfg *config.Config) Strategy {
+	strategy, err := New(cfg.Scheduler.Strategy, cfg.Scheduler.CooldownDays)
+	if err != nil {
+		log.Printf("[SCHEDULER] %v, using %s", err, StrategyLeastAsked)
+		return LeastAsked{}
+	}
+	return strategy
+}
+
+// ErrNoneReady is returned by Next when there are approved questions, but the
+// strategy may not ask any of them yet, e.g. because all are in cooldown
+var ErrNoneReady = errors.New("no approved question may be asked yet")
+
+// Next returns the next daily question, or nil if there are no approved
+// questions. The caller marks it as asked with IncrementQuestionUsage.
+func Next(source Source, strategy Strategy, now time.Time) (*database.Question, error) {
+	approved, err := source.GetApprovedQuestions()
+	if err != nil {
+		return nil, err
+	}
+	next := strategy.Next(approved, now)
+	if next == nil && len(approved) > 0 {
+		return nil, ErrNoneReady
+	}
+	return next, nil
+}
+
+// Preview returns the next n daily questions without asking any of them. Each
+// pick is treated as asked at now before the next one is made, which is what
+// happens if the questions are asked in a row. Fewer than n are returned if
+// the strategy runs out.
+func Preview(source Source, strategy Strategy, now time.Time, n int) ([]*database.Question, error) {
+	approved, err := source.GetApprovedQuestions()
+	if err != nil {
+		return nil, err
+	}
+
+	// Work on copies so the preview never touches the questions
+	simulated := make([]*database.Question, len(approved))
+	for i, q := range approved {
+		c := *q
+		simulated[i] = &c
+	}
+
+	var picks []*database.Question
+	for len(picks) < n {
+		next := strategy.Next(simulated, now)
+		if next == nil {
+			break
+		}
+		pick := *next
+		picks = append(picks, &pick)
+
+		next.TimesAsked++
+		askedAt := now
+		next.LastAskedAt = &askedAt
+	}
+	return picks, nil
+}
@@ -0,0 +1,47 @@
+package questions
+
+import (
+	"testing"
+	"time"
+
+	"askeladden/internal/database/databasetest"
+)
+
+func TestNextAsksLeastAskedInTurn(t *testing.T) {
+	db := databasetest.New()
+	for _, q := range []string{"Første?", "Andre?", "Tredje?"} {
+		id, _ := db.AddQuestion(q, "u1", "brukar", "m", "c")
+		db.ApproveQuestion(int(id), "opplysar")
+	}
+
+	var asked []string
+	for i := 0; i < 4; i++ {
+		q, err := Next(db, LeastAsked{}, now)
+		if err != nil || q == nil {
+			t.Fatalf("Next() = %v, %v", q, err)
+		}
+		asked = append(asked, q.Question)
+		db.IncrementQuestionUsage(q.ID)
+	}
+
+	want := []string{"Første?", "Andre?", "Tredje?", "Første?"}
+	for i := range want {
+		if asked[i] != want[i] {
+			t.Fatalf("asked order = %v, want %v", asked, want)
+		}
+	}
+}
+
+func TestNextWhenNothingCanBeAsked(t *testing.T) {
+	if q, err := Next(staticSource{}, Cooldown{Days: 30}, now); q != nil || err != nil {
+		t.Errorf("Next() without questions = %v, %v, want nil, nil", q, err)
+	}
+
+	recent := staticSource{question(1, 1, 2), question(2, 3, 10)}
+	if q, err := Next(recent, Cooldown{Days: 30}, now); q != nil || err != ErrNoneReady {
+		t.Errorf("Next() with every question in cooldown = %v, %v, want ErrNoneReady", q, err)
+	}
+	if q, err := Next(recent, Cooldown{Days: 30}, now.Add(30*24*time.Hour)); q == nil || err != nil {
+		t.Errorf("Next() after the cooldown = %v, %v", q, err)
+	}
+}
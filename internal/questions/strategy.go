@@ -0,0 +1,163 @@
+// Package questions vel kva for godkjent spørsmål som skal vere dagens
+// spørsmål. Korleis det vert valt, er ein strategi som vert sett i
+// scheduler-konfigurasjonen, og same strategi vert brukt av planleggaren og
+// !poke, slik at ei førehandsvising viser det som faktisk kjem.
+package questions
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"sort"
+	"time"
+
+	"askeladden/internal/database"
+)
+
+// Strategy names used in the scheduler config
+const (
+	StrategyLeastAsked     = "least_asked"
+	StrategyWeightedRandom = "weighted_random"
+	StrategyCooldown       = "cooldown"
+	StrategyExhaust        = "exhaust"
+)
+
+// DefaultCooldownDays is the cooldown when the config doesn't set one
+const DefaultCooldownDays = 30
+
+// Strategy picks the next daily question among the approved questions. Next
+// must not change the questions, and must give the same pick for the same
+// questions, so that a preview shows what will actually be asked.
+type Strategy interface {
+	// Name returns the config name of the strategy
+	Name() string
+	// Next returns the question to ask at now, or nil if none may be asked
+	Next(questions []*database.Question, now time.Time) *database.Question
+}
+
+// New returns the strategy with the given config name. An empty name gives
+// least asked first, which is how questions were always picked.
+func New(name string, cooldownDays int) (Strategy, error) {
+	switch name {
+	case "", StrategyLeastAsked:
+		return LeastAsked{}, nil
+	case StrategyWeightedRandom:
+		return WeightedRandom{}, nil
+	case StrategyCooldown:
+		if cooldownDays <= 0 {
+			cooldownDays = DefaultCooldownDays
+		}
+		return Cooldown{Days: cooldownDays}, nil
+	case StrategyExhaust:
+		return Exhaust{}, nil
+	default:
+		return nil, fmt.Errorf("unknown question strategy %q (use %s, %s, %s or %s)",
+			name, StrategyLeastAsked, StrategyWeightedRandom, StrategyCooldown, StrategyExhaust)
+	}
+}
+
+// LeastAsked picks the question asked the fewest times, the oldest first on
+// ties, so every question is asked once before any is asked twice
+type LeastAsked struct{}
+
+func (LeastAsked) Name() string { return StrategyLeastAsked }
+
+func (LeastAsked) Next(questions []*database.Question, now time.Time) *database.Question {
+	if len(questions) == 0 {
+		return nil
+	}
+	return byTimesAsked(questions)[0]
+}
+
+// WeightedRandom picks any question at random, with a weight of 1/(n+1) for a
+// question asked n times, so rarely asked questions come up more often
+type WeightedRandom struct{}
+
+func (WeightedRandom) Name() string { return StrategyWeightedRandom }
+
+func (WeightedRandom) Next(questions []*database.Question, now time.Time) *database.Question {
+	if len(questions) == 0 {
+		return nil
+	}
+	weights := make([]float64, len(questions))
+	total := 0.0
+	for i, q := range questions {
+		weights[i] = 1 / float64(q.TimesAsked+1)
+		total += weights[i]
+	}
+
+	r := seededRand(questions).Float64() * total
+	for i, weight := range weights {
+		if r < weight {
+			return questions[i]
+		}
+		r -= weight
+	}
+	return questions[len(questions)-1]
+}
+
+// Cooldown picks the least asked question among those not asked in the last
+// Days days. If every question was asked too recently, nothing is picked.
+type Cooldown struct {
+	Days int
+}
+
+func (c Cooldown) Name() string { return StrategyCooldown }
+
+func (c Cooldown) Next(questions []*database.Question, now time.Time) *database.Question {
+	cutoff := now.AddDate(0, 0, -c.Days)
+	var ready []*database.Question
+	for _, q := range questions {
+		if q.LastAskedAt == nil || !q.LastAskedAt.After(cutoff) {
+			ready = append(ready, q)
+		}
+	}
+	return LeastAsked{}.Next(ready, now)
+}
+
+// Exhaust picks at random among the questions asked the fewest times, so no
+// question is repeated before all have been asked, but the order of each round
+// is shuffled
+type Exhaust struct{}
+
+func (Exhaust) Name() string { return StrategyExhaust }
+
+func (Exhaust) Next(questions []*database.Question, now time.Time) *database.Question {
+	if len(questions) == 0 {
+		return nil
+	}
+	sorted := byTimesAsked(questions)
+	round := sorted[:1]
+	for _, q := range sorted[1:] {
+		if q.TimesAsked == round[0].TimesAsked {
+			round = append(round, q)
+		}
+	}
+	return round[seededRand(round).Intn(len(round))]
+}
+
+// byTimesAsked returns the questions sorted by times asked, then age
+func byTimesAsked(questions []*database.Question) []*database.Question {
+	sorted := append([]*database.Question(nil), questions...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].TimesAsked != sorted[j].TimesAsked {
+			return sorted[i].TimesAsked < sorted[j].TimesAsked
+		}
+		if !sorted[i].CreatedAt.Equal(sorted[j].CreatedAt) {
+			return sorted[i].CreatedAt.Before(sorted[j].CreatedAt)
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+	return sorted
+}
+
+// seededRand returns a random source seeded from the questions and how often
+// each has been asked. The random strategies are then repeatable: the same
+// questions give the same pick until a question is asked or added.
+func seededRand(questions []*database.Question) *rand.Rand {
+	h := fnv.New64a()
+	for _, q := range questions {
+		fmt.Fprintf(h, "%d:%d;", q.ID, q.TimesAsked)
+	}
+	return rand.New(rand.NewSource(int64(h.Sum64())))
+}
@@ -0,0 +1,145 @@
+package questions
+
+import (
+	"testing"
+	"time"
+
+	"askeladden/internal/database"
+)
+
+var now = time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+
+// question is an approved question created id days before now, asked
+// timesAsked times, the last time daysAgo days before now
+func question(id, timesAsked, daysAgo int) *database.Question {
+	q := &database.Question{ID: id, TimesAsked: timesAsked, CreatedAt: now.AddDate(0, 0, -100+id), ApprovalStatus: "approved"}
+	if timesAsked > 0 {
+		last := now.AddDate(0, 0, -daysAgo)
+		q.LastAskedAt = &last
+	}
+	return q
+}
+
+// staticSource serves a fixed list of approved questions
+type staticSource []*database.Question
+
+func (s staticSource) GetApprovedQuestions() ([]*database.Question, error) {
+	return s, nil
+}
+
+// ids returns the IDs of the picked questions
+func ids(picks []*database.Question) []int {
+	var result []int
+	for _, q := range picks {
+		result = append(result, q.ID)
+	}
+	return result
+}
+
+func equalIDs(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestNew(t *testing.T) {
+	for _, name := range []string{"", StrategyLeastAsked, StrategyWeightedRandom, StrategyCooldown, StrategyExhaust} {
+		if _, err := New(name, 0); err != nil {
+			t.Errorf("New(%q) failed: %v", name, err)
+		}
+	}
+	if s, _ := New(StrategyCooldown, 0); s.(Cooldown).Days != DefaultCooldownDays {
+		t.Errorf("cooldown without days = %+v", s)
+	}
+	if _, err := New("tilfeldig", 0); err == nil {
+		t.Error("unknown strategy should fail")
+	}
+}
+
+func TestLeastAskedPreview(t *testing.T) {
+	source := staticSource{question(1, 2, 10), question(2, 1, 20), question(3, 1, 5)}
+
+	picks, err := Preview(source, LeastAsked{}, now, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ids(picks); !equalIDs(got, []int{2, 3, 1, 2, 3}) {
+		t.Errorf("picks = %v", got)
+	}
+	if source[1].TimesAsked != 1 || source[1].LastAskedAt.Equal(now) {
+		t.Error("preview changed the questions")
+	}
+}
+
+func TestCooldownSkipsRecentQuestions(t *testing.T) {
+	source := staticSource{question(1, 0, 0), question(2, 1, 40), question(3, 0, 0), question(4, 1, 3)}
+
+	picks, _ := Preview(source, Cooldown{Days: 30}, now, 5)
+	if got := ids(picks); !equalIDs(got, []int{1, 3, 2}) {
+		t.Errorf("picks = %v, want the three questions outside the cooldown", got)
+	}
+	if next := (Cooldown{Days: 30}).Next(staticSource{question(1, 1, 2)}, now); next != nil {
+		t.Errorf("picked %+v inside the cooldown", next)
+	}
+}
+
+func TestExhaustAsksEveryQuestionOncePerRound(t *testing.T) {
+	var source staticSource
+	for id := 1; id <= 6; id++ {
+		source = append(source, question(id, 0, 0))
+	}
+
+	picks, _ := Preview(source, Exhaust{}, now, 12)
+	for round := 0; round < 2; round++ {
+		seen := make(map[int]bool)
+		for _, q := range picks[round*6 : (round+1)*6] {
+			if seen[q.ID] {
+				t.Fatalf("question %d repeated in round %d: %v", q.ID, round+1, ids(picks))
+			}
+			seen[q.ID] = true
+		}
+	}
+	if equalIDs(ids(picks[:6]), []int{1, 2, 3, 4, 5, 6}) && equalIDs(ids(picks[6:]), []int{1, 2, 3, 4, 5, 6}) {
+		t.Errorf("rounds are not shuffled: %v", ids(picks))
+	}
+}
+
+func TestRandomStrategiesAreRepeatable(t *testing.T) {
+	source := staticSource{question(1, 0, 0), question(2, 3, 10), question(3, 1, 20), question(4, 0, 0)}
+
+	for _, strategy := range []Strategy{WeightedRandom{}, Exhaust{}} {
+		first, _ := Preview(source, strategy, now, 8)
+		second, _ := Preview(source, strategy, now, 8)
+		if !equalIDs(ids(first), ids(second)) {
+			t.Errorf("%s gave %v and then %v", strategy.Name(), ids(first), ids(second))
+		}
+		if next, _ := Next(source, strategy, now); next.ID != first[0].ID {
+			t.Errorf("%s: Next = %d, preview starts with %d", strategy.Name(), next.ID, first[0].ID)
+		}
+	}
+}
+
+func TestWeightedRandomFavoursRarelyAsked(t *testing.T) {
+	counts := make(map[int]int)
+	for seed := 0; seed < 200; seed++ {
+		source := staticSource{question(1, 0, 0), question(2, 20, 1), question(seed+10, 0, 0)}
+		counts[WeightedRandom{}.Next(source, now).ID]++
+	}
+	if counts[2] > 30 {
+		t.Errorf("question asked 20 times was picked %d of 200 times", counts[2])
+	}
+}
+
+func TestEmptySource(t *testing.T) {
+	for _, strategy := range []Strategy{LeastAsked{}, WeightedRandom{}, Cooldown{Days: 1}, Exhaust{}} {
+		if picks, _ := Preview(staticSource{}, strategy, now, 3); len(picks) != 0 {
+			t.Errorf("%s picked %v from nothing", strategy.Name(), ids(picks))
+		}
+	}
+}
@@ -25,6 +25,11 @@ func handleBannedWordApprovalReaction(s *discordgo.Session, r *discordgo.Message
 		return
 	}
 
+	// Rejected or withdrawn reports are closed
+	if bannedWord.ApprovalStatus == "rejected" {
+		return
+	}
+
 	// Initialize permission manager
 	permManager := permissions.NewPermissionManager(b.Config)
 
@@ -54,28 +59,20 @@ func handleBannedWordApprovalReaction(s *discordgo.Session, r *discordgo.Message
 			log.Printf("Failed to approve banned word: %v", err)
 			return
 		}
+		b.BannedWords.Invalidate()
 
-		// Create forum thread for discussion
+		// Move the discussion thread from pending to approved
 		approvalService := services.ApprovalService{Bot: b}
-		originalMessageID := ""
-		originalChannelID := ""
-		if bannedWord.OriginalMessageID != nil {
-			// Parse the combined channel|message format
-			parts := strings.Split(*bannedWord.OriginalMessageID, "|")
-			if len(parts) == 2 {
-				originalChannelID = parts[0]
-				originalMessageID = parts[1]
-			} else {
-				// Fallback for old format (just message ID)
-				originalMessageID = *bannedWord.OriginalMessageID
-				originalChannelID = ""
+		if approved, err := b.Database.GetBannedWordByID(bannedWord.ID); err != nil {
+			log.Printf("Failed to reload approved banned word: %v", err)
+		} else {
+			// Words reported before threads were started on report have none yet
+			if approved.ForumThreadID == nil || *approved.ForumThreadID == "" {
+				if approvalService.StartForumThread(s, approved, r.GuildID) != nil {
+					b.BannedWords.Invalidate()
+				}
 			}
-		}
-		thread := approvalService.PostBannedWordReport(s, []string{bannedWord.Word}, bannedWord.AuthorID, r.GuildID, originalChannelID, originalMessageID)
-		if thread != nil {
-			log.Printf("Created forum thread %s for banned word %s", thread.ID, bannedWord.Word)
-			// Update the banned word with the forum thread ID
-			b.Database.UpdateBannedWordForumThreadID(int(bannedWord.ID), thread.ID)
+			approvalService.SyncForumThread(s, approved)
 		}
 
 		embedColor = services.ColorSuccess // Green
@@ -111,10 +108,24 @@ func handleBannedWordApprovalReaction(s *discordgo.Session, r *discordgo.Message
 			IconURL: avatarURL,
 		},
 	}
+	updatedEmbed.Fields = append(updatedEmbed.Fields, services.BannedWordCategoryField(bannedWord))
+	if field := services.DictionaryField(b.Dictionary, bannedWord.Word); field != nil {
+		updatedEmbed.Fields = append(updatedEmbed.Fields, field)
+	}
+	if len(bannedWord.Suggestions) > 0 {
+		updatedEmbed.Fields = append(updatedEmbed.Fields, &discordgo.MessageEmbedField{
+			Name:  "💡 Forslag",
+			Value: strings.Join(bannedWord.Suggestions, ", "),
+		})
+	}
 	s.ChannelMessageEditEmbed(r.ChannelID, r.MessageID, updatedEmbed)
 }
 
 func handleApprovalReaction(s *discordgo.Session, r *discordgo.MessageReactionAdd, b *bot.Bot) {
+	if !isApprovalChannel(b, r.ChannelID) {
+		return
+	}
+
 	// Try to find a banned word first
 	_, err := b.Database.GetBannedWordByApprovalMessageID(r.MessageID)
 	if err == nil {
@@ -123,6 +134,12 @@ func handleApprovalReaction(s *discordgo.Session, r *discordgo.MessageReactionAd
 		return
 	}
 
+	// Then an unban proposal
+	if proposal, err := b.Database.GetUnbanProposalByApprovalMessageID(r.MessageID); err == nil {
+		handleUnbanApprovalReaction(s, r, b, proposal)
+		return
+	}
+
 	// If no banned word found, try to find a question
 	question, err := b.Database.GetQuestionByApprovalMessageID(r.MessageID)
 	if err != nil {
@@ -135,6 +152,12 @@ func handleApprovalReaction(s *discordgo.Session, r *discordgo.MessageReactionAd
 }
 
 func handleQuestionApprovalReaction(s *discordgo.Session, r *discordgo.MessageReactionAdd, b *bot.Bot, question *database.Question) {
+	// Only opplysarar can approve questions
+	approvalService := &services.ApprovalService{Bot: b}
+	if !approvalService.UserHasOpplysarRole(s, r.GuildID, r.UserID) {
+		return
+	}
+
 	// Approve the question
 	err := b.Database.ApproveQuestion(question.ID, r.UserID)
 	if err != nil {
@@ -145,7 +168,6 @@ func handleQuestionApprovalReaction(s *discordgo.Session, r *discordgo.MessageRe
 	log.Printf("Question approved by opplysar %s: %s", r.UserID, question.Question)
 
 	// Notify the original user
-	approvalService := &services.ApprovalService{Bot: b}
 	approvalService.NotifyUserApproval(s, question, r.UserID)
 
 	// Get the approver's info for the approval message
@@ -180,3 +202,10 @@ func handleQuestionApprovalReaction(s *discordgo.Session, r *discordgo.MessageRe
 	}
 	s.ChannelMessageEditEmbed(r.ChannelID, r.MessageID, approvedEmbed)
 }
+
+// isApprovalChannel reports whether approval messages can be in the channel.
+// 👍 and 👎 are common everywhere, so other channels are skipped before any
+// database lookups.
+func isApprovalChannel(b *bot.Bot, channelID string) bool {
+	return channelID == b.Config.Approval.QueueChannelID || channelID == b.Config.BannedWords.ApprovalChannelID
+}
@@ -0,0 +1,157 @@
+package reactions
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+
+	"askeladden/internal/bot/bottest"
+	"askeladden/internal/bot/services"
+	"askeladden/internal/database"
+)
+
+// newReaction creates a reaction event on a message in the given channel
+func newReaction(userID, channelID, messageID, emoji string) *discordgo.MessageReactionAdd {
+	return &discordgo.MessageReactionAdd{MessageReaction: &discordgo.MessageReaction{
+		UserID:    userID,
+		MessageID: messageID,
+		ChannelID: channelID,
+		GuildID:   bottest.GuildID,
+		Emoji:     discordgo.Emoji{Name: emoji},
+	}}
+}
+
+// lastEdit decodes the most recent embed edit of a message
+func lastEdit(t *testing.T, discord *bottest.Discord, channelID, messageID string) *discordgo.MessageEmbed {
+	t.Helper()
+	edits := discord.Requests("PATCH", "/channels/"+channelID+"/messages/"+messageID)
+	if len(edits) == 0 {
+		t.Fatal("approval message was not edited")
+	}
+	var edit discordgo.MessageEdit
+	if err := edits[len(edits)-1].Decode(&edit); err != nil || edit.Embeds == nil || len(*edit.Embeds) == 0 {
+		t.Fatalf("could not decode edit: %v", err)
+	}
+	return (*edit.Embeds)[0]
+}
+
+func TestQuestionApprovalReaction(t *testing.T) {
+	b, discord, db := bottest.New()
+	id, _ := db.AddQuestion("Kva les du no?", "u1", "brukar", "m1", "c")
+	db.UpdateApprovalMessageID(int(id), "approval-msg")
+	discord.SetMember("opp", bottest.OpplysarRoleID)
+
+	handleApprovalReaction(b.Session, newReaction("opp", bottest.QueueChannelID, "approval-msg", "👍"), b)
+
+	q, _ := db.GetQuestionByMessageID("m1")
+	if q.ApprovalStatus != "approved" || *q.ApprovedBy != "opp" {
+		t.Fatalf("question = %+v, want approved by opp", q)
+	}
+	if dms := discord.Requests("POST", "/users/@me/channels"); len(dms) != 1 {
+		t.Errorf("expected the author to be notified, got %d DM channels", len(dms))
+	}
+	if embed := lastEdit(t, discord, bottest.QueueChannelID, "approval-msg"); embed.Color != services.ColorSuccess {
+		t.Errorf("approval embed colour = %#x, want success", embed.Color)
+	}
+}
+
+func TestQuestionApprovalRequiresOpplysar(t *testing.T) {
+	b, discord, db := bottest.New()
+	id, _ := db.AddQuestion("Kva les du no?", "u1", "brukar", "m1", "c")
+	db.UpdateApprovalMessageID(int(id), "approval-msg")
+	discord.SetMember("rett", bottest.RettskrivarRoleID)
+
+	handleApprovalReaction(b.Session, newReaction("rett", bottest.QueueChannelID, "approval-msg", "👍"), b)
+
+	if q, _ := db.GetQuestionByMessageID("m1"); q.ApprovalStatus != "pending" {
+		t.Errorf("question approved by a rettskrivar: %q", q.ApprovalStatus)
+	}
+}
+
+func TestBannedWordNeedsBothRoles(t *testing.T) {
+	b, discord, db := bottest.New()
+	id, _ := db.AddBannedWordPending("ikke", "", "u1", "brukar", "", "c|m")
+	db.UpdateBannedWordApprovalMessageID(int(id), "bw-msg")
+	discord.SetMember("opp", bottest.OpplysarRoleID)
+	discord.SetMember("rett", bottest.RettskrivarRoleID)
+
+	if found := b.BannedWords.FindAll("ikke"); len(found) != 0 {
+		t.Fatal("pending word should not be matched")
+	}
+
+	reactionsPath := "/channels/" + bottest.RettingChannelID + "/messages/bw-msg/reactions/👍"
+	discord.Respond("GET", reactionsPath, []*discordgo.User{{ID: "opp"}, {ID: bottest.BotUserID, Bot: true}})
+
+	handleApprovalReaction(b.Session, newReaction("opp", bottest.RettingChannelID, "bw-msg", "👍"), b)
+
+	if bw, _ := db.GetBannedWordByID(int(id)); bw.ApprovalStatus != "pending" {
+		t.Fatalf("status after opplysar only = %q, want pending", bw.ApprovalStatus)
+	}
+	if embed := lastEdit(t, discord, bottest.RettingChannelID, "bw-msg"); embed.Color != services.ColorWarning {
+		t.Errorf("partial approval colour = %#x, want warning", embed.Color)
+	}
+
+	discord.Respond("GET", reactionsPath, []*discordgo.User{{ID: "opp"}, {ID: "rett"}})
+	handleApprovalReaction(b.Session, newReaction("rett", bottest.RettingChannelID, "bw-msg", "👍"), b)
+
+	bw, _ := db.GetBannedWordByID(int(id))
+	if bw.ApprovalStatus != "fully_approved" || *bw.OpplysarApprovedBy != "opp" || *bw.RettskrivarApprovedBy != "rett" {
+		t.Fatalf("banned word = %+v, want fully approved by opp and rett", bw)
+	}
+	if embed := lastEdit(t, discord, bottest.RettingChannelID, "bw-msg"); embed.Color != services.ColorSuccess {
+		t.Errorf("full approval colour = %#x, want success", embed.Color)
+	}
+	if found := b.BannedWords.FindAll("ikke"); len(found) != 1 {
+		t.Error("approved word not matched, matcher was not invalidated")
+	}
+}
+
+func TestBannedWordReactionFromUserWithoutRole(t *testing.T) {
+	b, discord, db := bottest.New()
+	id, _ := db.AddBannedWordPending("ikke", "", "u1", "brukar", "", "c|m")
+	db.UpdateBannedWordApprovalMessageID(int(id), "bw-msg")
+	discord.SetMember("nobody")
+
+	handleApprovalReaction(b.Session, newReaction("nobody", bottest.RettingChannelID, "bw-msg", "👍"), b)
+
+	if bw, _ := db.GetBannedWordByID(int(id)); bw.ApprovalStatus != "pending" {
+		t.Errorf("status = %q, want pending", bw.ApprovalStatus)
+	}
+	if edits := discord.Requests("PATCH", "/channels/"+bottest.RettingChannelID+"/messages/bw-msg"); len(edits) != 0 {
+		t.Errorf("approval message edited for a user without roles")
+	}
+}
+
+func TestBannedWordApprovalMovesThreadToGodkjent(t *testing.T) {
+	b, discord, db := bottest.New()
+	id, _ := db.AddBannedWordPending("ikke", "", "u1", "brukar", "traad", "c|m")
+	db.UpdateBannedWordApprovalMessageID(int(id), "bw-msg")
+	respondWithThread(discord)
+	discord.SetMember("opp", bottest.OpplysarRoleID)
+	discord.SetMember("rett", bottest.RettskrivarRoleID)
+	discord.Respond("GET", "/channels/"+bottest.RettingChannelID+"/messages/bw-msg/reactions/👍", []*discordgo.User{{ID: "opp"}, {ID: "rett"}})
+
+	handleApprovalReaction(b.Session, newReaction("rett", bottest.RettingChannelID, "bw-msg", "👍"), b)
+
+	if starts := discord.Requests("POST", "/channels/"+bottest.GrammarChannelID+"/threads"); len(starts) != 0 {
+		t.Errorf("a second thread was started: %+v", starts)
+	}
+	edits := discord.ChannelEdits("traad")
+	if len(edits) != 1 || strings.Join(*edits[0].AppliedTags, ",") != "eiga,tag-godkjent" {
+		t.Errorf("thread edits = %+v, want godkjent", edits)
+	}
+}
+
+// noDatabase panics on any database call
+type noDatabase struct {
+	database.DatabaseIface
+}
+
+func TestThumbsOutsideApprovalChannelsSkipDatabase(t *testing.T) {
+	b, _, _ := bottest.New()
+	b.Database = noDatabase{}
+
+	handleApprovalReaction(b.Session, newReaction("u1", bottest.DefaultChannelID, "msg", "👍"), b)
+	handleRejectReaction(b.Session, newReaction("u1", bottest.DefaultChannelID, "msg", "👎"), b)
+}
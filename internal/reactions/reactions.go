@@ -76,9 +76,10 @@ func InitializeReactions(b *bot.Bot) {
 	// Register question reaction
 	RegisterQuestionReaction(b)
 
-	// Register approval reaction (static emoji)
-	Register("👍", "Godkjenn eit spørsmål.", handleApprovalReaction).SetAdminOnly()
+	// Register approval reaction (static emoji). Not admin-only, since banned
+	// words also need rettskrivarar; the handlers check roles themselves.
+	Register("👍", "Godkjenn eit spørsmål eller forbode ord.", handleApprovalReaction)
 
 	// Register reject reaction (static emoji)
-	Register("👎", "Avvis eit spørsmål.", handleRejectReaction).SetAdminOnly()
+	Register("👎", "Avvis eit spørsmål eller forbode ord, eller trekk tilbake din eigen ordrapport.", handleRejectReaction)
 }
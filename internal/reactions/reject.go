@@ -6,12 +6,34 @@ import (
 
 	"askeladden/internal/bot"
 	"askeladden/internal/bot/services"
+	"askeladden/internal/database"
+	"askeladden/internal/permissions"
 	"github.com/bwmarrin/discordgo"
 )
 
+// RejectReasonButtonPrefix starts the custom ID of the button that lets a
+// rejector add a reason, followed by the banned word ID
+const RejectReasonButtonPrefix = "reject_reason:"
+
 // handleRejectReaction is registered dynamically in InitializeReactions
 
 func handleRejectReaction(s *discordgo.Session, r *discordgo.MessageReactionAdd, b *bot.Bot) {
+	if !isApprovalChannel(b, r.ChannelID) {
+		return
+	}
+
+	// Banned word reports can be rejected by either role, or withdrawn by the reporter
+	if bannedWord, err := b.Database.GetBannedWordByApprovalMessageID(r.MessageID); err == nil {
+		handleBannedWordRejectReaction(s, r, b, bannedWord)
+		return
+	}
+
+	// Unban proposals follow the same rules
+	if proposal, err := b.Database.GetUnbanProposalByApprovalMessageID(r.MessageID); err == nil {
+		handleUnbanRejectReaction(s, r, b, proposal)
+		return
+	}
+
 	// Get the question by approval message ID
 	question, err := b.Database.GetQuestionByApprovalMessageID(r.MessageID)
 	if err != nil {
@@ -19,6 +41,12 @@ func handleRejectReaction(s *discordgo.Session, r *discordgo.MessageReactionAdd,
 		return
 	}
 
+	// Only opplysarar can reject questions
+	approvalService := &services.ApprovalService{Bot: b}
+	if !approvalService.UserHasOpplysarRole(s, r.GuildID, r.UserID) {
+		return
+	}
+
 	// Reject the question
 	err = b.Database.RejectQuestion(question.ID, r.UserID)
 	if err != nil {
@@ -29,10 +57,70 @@ func handleRejectReaction(s *discordgo.Session, r *discordgo.MessageReactionAdd,
 	log.Printf("Question rejected by opplysar %s: %s", r.UserID, question.Question)
 
 	// Notify the original user
-	approvalService := &services.ApprovalService{Bot: b}
 	approvalService.NotifyUserRejection(s, question, r.UserID)
 
 	// Update the approval message to indicate it's been processed
 	rejectedEmbed := services.CreateBotEmbed(s, "❌ AVVIST", fmt.Sprintf("**Spørsmål:** %s\n**Frå:** %s\n**Avvist av:** <@%s>", question.Question, question.AuthorName, r.UserID), services.EmbedTypeError)
 	s.ChannelMessageEditEmbed(r.ChannelID, r.MessageID, rejectedEmbed)
 }
+
+// handleBannedWordRejectReaction rejects a pending banned word report. When the
+// reporter reacts on their own report it counts as a withdrawal.
+func handleBannedWordRejectReaction(s *discordgo.Session, r *discordgo.MessageReactionAdd, b *bot.Bot, bannedWord *database.BannedWord) {
+	if bannedWord.ApprovalStatus != "pending" {
+		return
+	}
+
+	withdrawn := r.UserID == bannedWord.AuthorID
+	if !withdrawn {
+		permManager := permissions.NewPermissionManager(b.Config)
+		if permManager.GetUserRole(s, r.GuildID, r.UserID) == permissions.RoleNone {
+			log.Printf("User %s does not have required roles for rejection", r.UserID)
+			return
+		}
+	}
+
+	if err := b.Database.RejectBannedWord(bannedWord.ID, r.UserID); err != nil {
+		log.Printf("Failed to reject banned word: %v", err)
+		return
+	}
+
+	// Reload to get the rejection details
+	bannedWord, err := b.Database.GetBannedWordByID(bannedWord.ID)
+	if err != nil {
+		log.Printf("Failed to reload rejected banned word: %v", err)
+		return
+	}
+
+	approvalService := &services.ApprovalService{Bot: b}
+	approvalService.SyncForumThread(s, bannedWord)
+
+	reporter, _ := s.User(bannedWord.AuthorID)
+	edit := discordgo.NewMessageEdit(r.ChannelID, r.MessageID).
+		SetEmbed(services.CreateBannedWordRejectionEmbed(bannedWord, reporter))
+
+	if withdrawn {
+		log.Printf("Banned word %s withdrawn by reporter %s", bannedWord.Word, r.UserID)
+		edit.Components = &[]discordgo.MessageComponent{}
+	} else {
+		log.Printf("Banned word %s rejected by %s", bannedWord.Word, r.UserID)
+		edit.Components = &[]discordgo.MessageComponent{
+			discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{
+					discordgo.Button{
+						Label:    "Legg til grunngiving",
+						Emoji:    &discordgo.ComponentEmoji{Name: "📝"},
+						Style:    discordgo.SecondaryButton,
+						CustomID: fmt.Sprintf("%s%d", RejectReasonButtonPrefix, bannedWord.ID),
+					},
+				},
+			},
+		}
+
+		approvalService.NotifyBannedWordRejection(s, bannedWord)
+	}
+
+	if _, err := s.ChannelMessageEditComplex(edit); err != nil {
+		log.Printf("Failed to update rejected banned word message: %v", err)
+	}
+}
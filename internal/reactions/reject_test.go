@@ -0,0 +1,116 @@
+package reactions
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+
+	"askeladden/internal/bot/bottest"
+	"askeladden/internal/bot/services"
+)
+
+// messageEdit is the part of a message edit the tests look at. Components
+// are decoded by hand since discordgo.MessageComponent is an interface.
+type messageEdit struct {
+	Embeds     []*discordgo.MessageEmbed `json:"embeds"`
+	Components []struct {
+		Components []struct {
+			CustomID string `json:"custom_id"`
+		} `json:"components"`
+	} `json:"components"`
+}
+
+// lastComplexEdit decodes the most recent edit of a message
+func lastComplexEdit(t *testing.T, discord *bottest.Discord, channelID, messageID string) messageEdit {
+	t.Helper()
+	edits := discord.Requests("PATCH", "/channels/"+channelID+"/messages/"+messageID)
+	if len(edits) == 0 {
+		t.Fatal("message was not edited")
+	}
+	var edit messageEdit
+	if err := edits[len(edits)-1].Decode(&edit); err != nil {
+		t.Fatalf("could not decode edit: %v", err)
+	}
+	return edit
+}
+
+func TestBannedWordRejectedByRettskrivar(t *testing.T) {
+	b, discord, db := bottest.New()
+	id, _ := db.AddBannedWordPending("ikkje", "", "melder", "melder", "", "c|m")
+	db.UpdateBannedWordApprovalMessageID(int(id), "bw-msg")
+	discord.SetMember("rett", bottest.RettskrivarRoleID)
+
+	handleRejectReaction(b.Session, newReaction("rett", bottest.RettingChannelID, "bw-msg", "👎"), b)
+
+	bw, _ := db.GetBannedWordByID(int(id))
+	if bw.ApprovalStatus != "rejected" || bw.RejectedBy == nil || *bw.RejectedBy != "rett" {
+		t.Fatalf("banned word = %+v, want rejected by rett", bw)
+	}
+
+	edit := lastComplexEdit(t, discord, bottest.RettingChannelID, "bw-msg")
+	if len(edit.Embeds) != 1 || edit.Embeds[0].Color != services.ColorNeutral || !strings.Contains(edit.Embeds[0].Description, "Avvist av <@rett>") {
+		t.Errorf("rejection embed = %+v", edit.Embeds)
+	}
+	if len(edit.Components) != 1 || edit.Components[0].Components[0].CustomID != RejectReasonButtonPrefix+"1" {
+		t.Fatalf("expected a reason button, got %+v", edit.Components)
+	}
+	if dms := discord.Requests("POST", "/users/@me/channels"); len(dms) != 1 {
+		t.Errorf("expected the reporter to get a DM, got %d", len(dms))
+	}
+
+	// Approving a rejected report must not reopen it
+	discord.SetMember("opp", bottest.OpplysarRoleID)
+	handleApprovalReaction(b.Session, newReaction("opp", bottest.RettingChannelID, "bw-msg", "👍"), b)
+	if bw, _ := db.GetBannedWordByID(int(id)); bw.ApprovalStatus != "rejected" {
+		t.Errorf("status after 👍 on rejected report = %q", bw.ApprovalStatus)
+	}
+}
+
+func TestBannedWordRejectionMovesThreadToAvvist(t *testing.T) {
+	b, discord, db := bottest.New()
+	id, _ := db.AddBannedWordPending("ikkje", "", "melder", "melder", "traad", "c|m")
+	db.UpdateBannedWordApprovalMessageID(int(id), "bw-msg")
+	respondWithThread(discord)
+	discord.SetMember("rett", bottest.RettskrivarRoleID)
+
+	handleRejectReaction(b.Session, newReaction("rett", bottest.RettingChannelID, "bw-msg", "👎"), b)
+
+	edits := discord.ChannelEdits("traad")
+	if len(edits) != 1 || strings.Join(*edits[0].AppliedTags, ",") != "eiga,tag-avvist" {
+		t.Errorf("thread edits = %+v, want avvist", edits)
+	}
+}
+
+func TestBannedWordWithdrawnByReporter(t *testing.T) {
+	b, discord, db := bottest.New()
+	id, _ := db.AddBannedWordPending("ikkje", "", "melder", "melder", "", "c|m")
+	db.UpdateBannedWordApprovalMessageID(int(id), "bw-msg")
+	discord.SetMember("melder")
+
+	handleRejectReaction(b.Session, newReaction("melder", bottest.RettingChannelID, "bw-msg", "👎"), b)
+
+	if bw, _ := db.GetBannedWordByID(int(id)); bw.ApprovalStatus != "rejected" || *bw.RejectedBy != "melder" {
+		t.Fatalf("banned word = %+v, want withdrawn", bw)
+	}
+	edit := lastComplexEdit(t, discord, bottest.RettingChannelID, "bw-msg")
+	if !strings.Contains(edit.Embeds[0].Description, "Trekt tilbake") || len(edit.Components) != 0 {
+		t.Errorf("withdrawal edit = %+v", edit)
+	}
+	if dms := discord.Requests("POST", "/users/@me/channels"); len(dms) != 0 {
+		t.Error("reporter should not be notified about their own withdrawal")
+	}
+}
+
+func TestBannedWordRejectionRequiresRole(t *testing.T) {
+	b, discord, db := bottest.New()
+	id, _ := db.AddBannedWordPending("ikkje", "", "melder", "melder", "", "c|m")
+	db.UpdateBannedWordApprovalMessageID(int(id), "bw-msg")
+	discord.SetMember("tilfeldig")
+
+	handleRejectReaction(b.Session, newReaction("tilfeldig", bottest.RettingChannelID, "bw-msg", "👎"), b)
+
+	if bw, _ := db.GetBannedWordByID(int(id)); bw.ApprovalStatus != "pending" {
+		t.Errorf("status = %q, want pending", bw.ApprovalStatus)
+	}
+}
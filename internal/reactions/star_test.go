@@ -0,0 +1,59 @@
+package reactions
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+
+	"askeladden/internal/bot/bottest"
+)
+
+// respondWithStars makes the fake API return the original message with n stars
+func respondWithStars(discord *bottest.Discord, n int) {
+	discord.Respond("GET", "/channels/"+bottest.DefaultChannelID+"/messages/orig", &discordgo.Message{
+		ID:        "orig",
+		ChannelID: bottest.DefaultChannelID,
+		Content:   "Ei stjerneverdig melding",
+		Author:    &discordgo.User{ID: "u1", Username: "brukar"},
+		Reactions: []*discordgo.MessageReactions{{Count: n, Emoji: &discordgo.Emoji{Name: "⭐"}}},
+	})
+}
+
+func TestStarboardLifecycle(t *testing.T) {
+	b, discord, db := bottest.New()
+	update := func(stars int) {
+		respondWithStars(discord, stars)
+		handleStarboardUpdate(b.Session, bottest.DefaultChannelID, "orig", bottest.GuildID, b)
+	}
+
+	update(bottest.DefaultStarThreshold - 1)
+	if sent := discord.SentMessages(bottest.StarboardChannelID); len(sent) != 0 {
+		t.Fatalf("message below threshold was posted to the starboard")
+	}
+
+	update(bottest.DefaultStarThreshold)
+	sent := discord.SentMessages(bottest.StarboardChannelID)
+	if len(sent) != 1 {
+		t.Fatalf("expected one starboard post, got %d", len(sent))
+	}
+	starboardID, _ := db.GetStarboardMessage("orig")
+	if starboardID == "" {
+		t.Fatal("starboard mapping was not recorded")
+	}
+
+	update(bottest.DefaultStarThreshold + 1)
+	if edits := discord.Requests("PATCH", "/channels/"+bottest.StarboardChannelID+"/messages/"+starboardID); len(edits) != 1 {
+		t.Fatalf("expected the existing starboard post to be edited, got %d edits", len(edits))
+	}
+	if sent := discord.SentMessages(bottest.StarboardChannelID); len(sent) != 1 {
+		t.Fatalf("a second starboard post was created")
+	}
+
+	update(bottest.DefaultStarThreshold - 1)
+	if deletes := discord.Requests("DELETE", "/channels/"+bottest.StarboardChannelID+"/messages/"+starboardID); len(deletes) != 1 {
+		t.Fatalf("expected the starboard post to be deleted, got %d deletes", len(deletes))
+	}
+	if id, _ := db.GetStarboardMessage("orig"); id != "" {
+		t.Errorf("starboard mapping still present after delete: %q", id)
+	}
+}
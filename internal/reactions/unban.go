@@ -0,0 +1,111 @@
+package reactions
+
+import (
+	"fmt"
+	"log"
+
+	"askeladden/internal/bot"
+	"askeladden/internal/bot/services"
+	"askeladden/internal/database"
+	"askeladden/internal/permissions"
+	"github.com/bwmarrin/discordgo"
+)
+
+// handleUnbanApprovalReaction handles 👍 on an unban proposal. Like a ban, lifting
+// it needs approval from both an opplysar and a rettskrivar.
+func handleUnbanApprovalReaction(s *discordgo.Session, r *discordgo.MessageReactionAdd, b *bot.Bot, proposal *database.UnbanProposal) {
+	if proposal.Status != "pending" {
+		return
+	}
+
+	permManager := permissions.NewPermissionManager(b.Config)
+	if permManager.GetUserRole(s, r.GuildID, r.UserID) == permissions.RoleNone {
+		log.Printf("User %s does not have required roles for unban approval", r.UserID)
+		return
+	}
+
+	approvalState, err := permManager.CheckCombinedApproval(s, r.ChannelID, r.MessageID, r.Emoji.Name)
+	if err != nil {
+		log.Printf("Failed to check combined approval: %v", err)
+		return
+	}
+
+	bannedWord, err := b.Database.GetBannedWordByID(proposal.WordID)
+	if err != nil {
+		log.Printf("Failed to get banned word for unban proposal %d: %v", proposal.ID, err)
+		return
+	}
+
+	color := services.ColorWarning
+	status := approvalState.GetApprovalSummary(s)
+
+	if approvalState.IsFullyApproved() {
+		if err := b.Database.ApproveUnbanProposal(proposal.ID, approvalState.OpplysarApprovers, approvalState.RettskrivarApprovers); err != nil {
+			log.Printf("Failed to approve unban proposal: %v", err)
+			return
+		}
+		b.BannedWords.Invalidate()
+		log.Printf("Ban on %s lifted by combined roles", bannedWord.Word)
+
+		proposal.Status = "approved"
+		approvalService := services.ApprovalService{Bot: b}
+		approvalService.PostUnbanOutcome(s, bannedWord, proposal, r.UserID)
+
+		// Lock the thread after the outcome is posted, since nobody can post in it after
+		if unbanned, err := b.Database.GetBannedWordByID(bannedWord.ID); err == nil {
+			approvalService.SyncForumThread(s, unbanned)
+		}
+
+		color = services.ColorSuccess
+		status = "🔓 Forbodet er oppheva\n\n" + status
+	} else {
+		log.Printf("Unban proposal for %s partially approved - waiting for additional roles", bannedWord.Word)
+	}
+
+	proposer, _ := s.User(proposal.ProposerID)
+	s.ChannelMessageEditEmbed(r.ChannelID, r.MessageID, services.CreateUnbanProposalEmbed(bannedWord, proposal, status, color, proposer))
+}
+
+// handleUnbanRejectReaction handles 👎 on an unban proposal. Either role can
+// reject it, and the proposer can withdraw it.
+func handleUnbanRejectReaction(s *discordgo.Session, r *discordgo.MessageReactionAdd, b *bot.Bot, proposal *database.UnbanProposal) {
+	if proposal.Status != "pending" {
+		return
+	}
+
+	withdrawn := r.UserID == proposal.ProposerID
+	if !withdrawn {
+		permManager := permissions.NewPermissionManager(b.Config)
+		if permManager.GetUserRole(s, r.GuildID, r.UserID) == permissions.RoleNone {
+			log.Printf("User %s does not have required roles for unban rejection", r.UserID)
+			return
+		}
+	}
+
+	if err := b.Database.RejectUnbanProposal(proposal.ID, r.UserID); err != nil {
+		log.Printf("Failed to reject unban proposal: %v", err)
+		return
+	}
+
+	bannedWord, err := b.Database.GetBannedWordByID(proposal.WordID)
+	if err != nil {
+		log.Printf("Failed to get banned word for unban proposal %d: %v", proposal.ID, err)
+		return
+	}
+
+	proposal.Status = "rejected"
+	approvalService := services.ApprovalService{Bot: b}
+	var status string
+	if withdrawn {
+		log.Printf("Unban proposal for %s withdrawn by proposer %s", bannedWord.Word, r.UserID)
+		status = fmt.Sprintf("↩️ Trekt tilbake av <@%s>", r.UserID)
+	} else {
+		log.Printf("Unban proposal for %s rejected by %s", bannedWord.Word, r.UserID)
+		status = fmt.Sprintf("❌ Avvist av <@%s>", r.UserID)
+		approvalService.PostUnbanOutcome(s, bannedWord, proposal, r.UserID)
+	}
+	approvalService.SyncForumThread(s, bannedWord)
+
+	proposer, _ := s.User(proposal.ProposerID)
+	s.ChannelMessageEditEmbed(r.ChannelID, r.MessageID, services.CreateUnbanProposalEmbed(bannedWord, proposal, status, services.ColorNeutral, proposer))
+}
@@ -0,0 +1,141 @@
+package reactions
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+
+	"askeladden/internal/bot/bottest"
+	"askeladden/internal/bot/services"
+	"askeladden/internal/database/databasetest"
+)
+
+// addUnbanProposal stores an approved banned word with a forum thread and a
+// pending proposal to lift it, posted as "unban-msg"
+func addUnbanProposal(db *databasetest.DB) (wordID, proposalID int) {
+	id, _ := db.AddBannedWordPending("ikke", "", "u1", "brukar", "traad", "c|m")
+	db.ApproveBannedWordCombined(int(id), []string{"opp"}, []string{"rett"})
+	pid, _ := db.AddUnbanProposal(int(id), "foreslar", "foreslar", "Vert brukt i sitat")
+	db.UpdateUnbanProposalApprovalMessageID(int(pid), "unban-msg")
+	return int(id), int(pid)
+}
+
+// respondWithThread makes the fake Discord return the forum thread, with a
+// tag set by hand next to the status tags
+func respondWithThread(discord *bottest.Discord) {
+	discord.Respond("GET", "/channels/traad", &discordgo.Channel{
+		ID:          "traad",
+		Type:        discordgo.ChannelTypeGuildPublicThread,
+		ParentID:    bottest.GrammarChannelID,
+		AppliedTags: []string{"tag-godkjent", "eiga", "tag-ventar"},
+	})
+}
+
+func TestUnbanNeedsBothRoles(t *testing.T) {
+	b, discord, db := bottest.New()
+	wordID, _ := addUnbanProposal(db)
+	respondWithThread(discord)
+	discord.SetMember("opp", bottest.OpplysarRoleID)
+	discord.SetMember("rett", bottest.RettskrivarRoleID)
+
+	if found := b.BannedWords.FindAll("ikke"); len(found) != 1 {
+		t.Fatal("banned word should be matched before the unban")
+	}
+
+	reactionsPath := "/channels/" + bottest.RettingChannelID + "/messages/unban-msg/reactions/👍"
+	discord.Respond("GET", reactionsPath, []*discordgo.User{{ID: "opp"}})
+	handleApprovalReaction(b.Session, newReaction("opp", bottest.RettingChannelID, "unban-msg", "👍"), b)
+
+	if bw, _ := db.GetBannedWordByID(wordID); !bw.IsActive() {
+		t.Fatal("ban lifted with opplysar approval only")
+	}
+	if embed := lastEdit(t, discord, bottest.RettingChannelID, "unban-msg"); embed.Color != services.ColorWarning {
+		t.Errorf("partial approval colour = %#x, want warning", embed.Color)
+	}
+
+	discord.Respond("GET", reactionsPath, []*discordgo.User{{ID: "opp"}, {ID: "rett"}})
+	handleApprovalReaction(b.Session, newReaction("rett", bottest.RettingChannelID, "unban-msg", "👍"), b)
+
+	bw, _ := db.GetBannedWordByID(wordID)
+	if bw.IsActive() || bw.UnbannedAt == nil || bw.ApprovalStatus != "fully_approved" {
+		t.Fatalf("banned word = %+v, want kept with unbanned_at set", bw)
+	}
+	if embed := lastEdit(t, discord, bottest.RettingChannelID, "unban-msg"); embed.Color != services.ColorSuccess {
+		t.Errorf("full approval colour = %#x, want success", embed.Color)
+	}
+	if found := b.BannedWords.FindAll("ikke"); len(found) != 0 {
+		t.Error("unbanned word still matched, matcher was not invalidated")
+	}
+	if embeds := discord.SentEmbeds("traad"); len(embeds) != 1 || !strings.Contains(embeds[0].Title, "oppheva") {
+		t.Errorf("forum thread embeds = %+v", embeds)
+	}
+
+	edits := discord.ChannelEdits("traad")
+	if len(edits) != 1 {
+		t.Fatalf("forum thread edited %d times, want 1", len(edits))
+	}
+	if tags := strings.Join(*edits[0].AppliedTags, ","); tags != "eiga,tag-oppheva" {
+		t.Errorf("thread tags = %s, want the hand-set tag and oppheva", tags)
+	}
+	if edits[0].Locked == nil || !*edits[0].Locked || edits[0].Archived == nil || !*edits[0].Archived {
+		t.Error("thread of an unbanned word was not locked and archived")
+	}
+}
+
+func TestUnbanRejectedByOneRole(t *testing.T) {
+	b, discord, db := bottest.New()
+	wordID, proposalID := addUnbanProposal(db)
+	respondWithThread(discord)
+	discord.SetMember("rett", bottest.RettskrivarRoleID)
+
+	handleRejectReaction(b.Session, newReaction("rett", bottest.RettingChannelID, "unban-msg", "👎"), b)
+
+	if p, _ := db.GetUnbanProposalByID(proposalID); p.Status != "rejected" || *p.DecidedBy != "rett" {
+		t.Fatalf("proposal = %+v, want rejected by rett", p)
+	}
+	if bw, _ := db.GetBannedWordByID(wordID); !bw.IsActive() {
+		t.Error("ban lifted by a rejected proposal")
+	}
+	if embed := lastEdit(t, discord, bottest.RettingChannelID, "unban-msg"); embed.Color != services.ColorNeutral {
+		t.Errorf("rejection colour = %#x, want neutral", embed.Color)
+	}
+	if embeds := discord.SentEmbeds("traad"); len(embeds) != 1 || !strings.Contains(embeds[0].Description, "<@rett>") {
+		t.Errorf("forum thread embeds = %+v", embeds)
+	}
+
+	edits := discord.ChannelEdits("traad")
+	if len(edits) != 1 || strings.Join(*edits[0].AppliedTags, ",") != "eiga,tag-godkjent" || edits[0].Locked != nil {
+		t.Errorf("thread edits = %+v, want back to godkjent and open", edits)
+	}
+}
+
+func TestUnbanWithdrawnByProposer(t *testing.T) {
+	b, discord, db := bottest.New()
+	_, proposalID := addUnbanProposal(db)
+	discord.SetMember("foreslar")
+
+	handleRejectReaction(b.Session, newReaction("foreslar", bottest.RettingChannelID, "unban-msg", "👎"), b)
+
+	if p, _ := db.GetUnbanProposalByID(proposalID); p.Status != "rejected" {
+		t.Fatalf("status = %q, want rejected", p.Status)
+	}
+	if embed := lastEdit(t, discord, bottest.RettingChannelID, "unban-msg"); !strings.Contains(embed.Description, "Trekt tilbake") {
+		t.Errorf("withdrawal embed = %+v", embed)
+	}
+	if embeds := discord.SentEmbeds("traad"); len(embeds) != 0 {
+		t.Errorf("withdrawal posted to the forum thread: %+v", embeds)
+	}
+}
+
+func TestUnbanReactionFromUserWithoutRole(t *testing.T) {
+	b, discord, db := bottest.New()
+	_, proposalID := addUnbanProposal(db)
+	discord.SetMember("nobody")
+
+	handleRejectReaction(b.Session, newReaction("nobody", bottest.RettingChannelID, "unban-msg", "👎"), b)
+
+	if p, _ := db.GetUnbanProposalByID(proposalID); p.Status != "pending" {
+		t.Errorf("status = %q, want pending", p.Status)
+	}
+}